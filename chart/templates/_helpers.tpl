@@ -51,3 +51,14 @@ Return the ingress hostname
 {{- define "kubebrowser.ingress.hostname" -}}
 {{- tpl .Values.ingress.hostname $ -}}
 {{- end -}}
+
+{{/*
+Return the source clusters of the federated catalog, as name=kubeconfig path pairs
+*/}}
+{{- define "kubebrowser.server.catalogSources" -}}
+{{- $sources := list -}}
+{{- range .Values.server.catalog.sources -}}
+{{- $sources = append $sources (printf "%s=/etc/kubebrowser/sources/%s/kubeconfig" .name .name) -}}
+{{- end -}}
+{{- join "," $sources -}}
+{{- end -}}
@@ -0,0 +1,467 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Label put on AccessRequests and AccessGrants to find them by Kubeconfig
+const kubeconfigLabel = "kubebrowser.io/kubeconfig"
+
+type accessRequestBody struct {
+	Kubeconfig string `json:"kubeconfig" binding:"required"`
+	Reason     string `json:"reason"`
+	Duration   string `json:"duration"`
+}
+
+type accessDecisionBody struct {
+	Message string `json:"message"`
+}
+
+// accessRequestView is the representation of an AccessRequest sent to the UI
+type accessRequestView struct {
+	Name        string                      `json:"name"`
+	Kubeconfig  string                      `json:"kubeconfig"`
+	DisplayName string                      `json:"displayName"`
+	Requester   string                      `json:"requester"`
+	Reason      string                      `json:"reason,omitempty"`
+	Duration    string                      `json:"duration"`
+	Phase       v1alpha1.AccessRequestPhase `json:"phase"`
+	DecidedBy   string                      `json:"decidedBy,omitempty"`
+	ExpiresAt   *metav1.Time                `json:"expiresAt,omitempty"`
+	Message     string                      `json:"message,omitempty"`
+	CanDecide   bool                        `json:"canDecide"`
+}
+
+// requestableView is a Kubeconfig the user can request access to
+type requestableView struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// Lists the AccessGrants, none without a cluster
+func listAccessGrants() ([]*v1alpha1.AccessGrant, error) {
+	if !kubecfg.HasCluster() {
+		return nil, nil
+	}
+	return kubecfg.grantLister.AccessGrants(viper.GetString(podNamespaceKey)).List(labels.Everything())
+}
+
+// Returns the AccessRequests made by the user or that the user can decide on
+func handleGetAccessRequests(c *gin.Context) {
+	logger.Debug("Entering handleGetAccessRequests")
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	namespace := viper.GetString(podNamespaceKey)
+	requests, err := kubecfg.requestLister.AccessRequests(namespace).List(labels.Everything())
+	if err != nil {
+		logger.Errorf("Error listing access requests: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing access requests")
+		return
+	}
+
+	views := make([]accessRequestView, 0, len(requests))
+	for _, request := range requests {
+		kubeconfig, err := getKubeconfig(request.Spec.Kubeconfig)
+		if err != nil {
+			logger.Debugw("Skipping access request on unknown kubeconfig", "name", request.Name, "kubeconfig", request.Spec.Kubeconfig)
+			continue
+		}
+
+		canDecide := canDecideAccessRequest(kubeconfig, request, claims)
+		if request.Spec.Requester != claims.Email && !canDecide {
+			continue
+		}
+
+		views = append(views, accessRequestView{
+			Name:        request.Name,
+			Kubeconfig:  request.Spec.Kubeconfig,
+			DisplayName: kubeconfig.Spec.Name,
+			Requester:   request.Spec.Requester,
+			Reason:      request.Spec.Reason,
+			Duration:    request.Spec.Duration.Duration.String(),
+			Phase:       accessRequestPhase(request),
+			DecidedBy:   request.Status.DecidedBy,
+			ExpiresAt:   request.Status.ExpiresAt,
+			Message:     request.Status.Message,
+			CanDecide:   canDecide,
+		})
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+// Returns the Kubeconfigs the user has no access to but can request access to
+func handleGetRequestableKubeconfigs(c *gin.Context) {
+	logger.Debug("Entering handleGetRequestableKubeconfigs")
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	configs, err := listKubeconfigs()
+	if err != nil {
+		logger.Errorf("Error listing kubeconfigs: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing kubeconfigs")
+		return
+	}
+	grants, err := listAccessGrants()
+	if err != nil {
+		logger.Errorf("Error listing access grants: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing access grants")
+		return
+	}
+
+	accessible := make(map[string]bool)
+	for _, kubeconfig := range filterKubeConfigs(configs, claims, grants) {
+		accessible[kubeconfigID(kubeconfig)] = true
+	}
+
+	views := make([]requestableView, 0)
+	for _, kubeconfig := range configs {
+		id := kubeconfigID(kubeconfig)
+		if kubeconfig.Spec.Approvers == nil || accessible[id] || kubeconfigDisabled(kubeconfig) {
+			continue
+		}
+		views = append(views, requestableView{Name: id, DisplayName: kubeconfig.Spec.Name})
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+func handleCreateAccessRequest(c *gin.Context) {
+	logger.Debug("Entering handleCreateAccessRequest")
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	var body accessRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.String(http.StatusBadRequest, "Invalid access request: "+err.Error())
+		return
+	}
+
+	duration := viper.GetDuration(accessRequestDefaultDurationKey)
+	if body.Duration != "" {
+		d, err := time.ParseDuration(body.Duration)
+		if err != nil || d <= 0 {
+			c.String(http.StatusBadRequest, "Invalid duration")
+			return
+		}
+		duration = d
+	}
+	if duration > viper.GetDuration(accessRequestMaxDurationKey) {
+		c.String(http.StatusBadRequest, "Duration exceeds maximum of "+viper.GetDuration(accessRequestMaxDurationKey).String())
+		return
+	}
+
+	namespace := viper.GetString(podNamespaceKey)
+	kubeconfig, err := getKubeconfig(body.Kubeconfig)
+	if apierrors.IsNotFound(err) {
+		c.String(http.StatusNotFound, "Kubeconfig not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting kubeconfig: %s", err)
+		c.String(http.StatusInternalServerError, "Error getting kubeconfig")
+		return
+	}
+	if kubeconfig.Spec.Approvers == nil || kubeconfigDisabled(kubeconfig) {
+		c.String(http.StatusBadRequest, "Kubeconfig does not accept access requests")
+		return
+	}
+
+	// Refuse a second pending request for the same Kubeconfig
+	requests, err := kubecfg.requestLister.AccessRequests(namespace).List(
+		labels.SelectorFromSet(labels.Set{kubeconfigLabel: kubeconfig.Name}),
+	)
+	if err != nil {
+		logger.Errorf("Error listing access requests: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing access requests")
+		return
+	}
+	for _, request := range requests {
+		if request.Spec.Kubeconfig == kubeconfigID(kubeconfig) && request.Spec.Requester == claims.Email &&
+			accessRequestPhase(request) == v1alpha1.AccessRequestPending {
+			c.String(http.StatusConflict, "An access request is already pending for this kubeconfig")
+			return
+		}
+	}
+
+	request := &v1alpha1.AccessRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: kubeconfig.Name + "-",
+			Labels:       map[string]string{kubeconfigLabel: kubeconfig.Name},
+		},
+		Spec: v1alpha1.AccessRequestSpec{
+			Kubeconfig: kubeconfigID(kubeconfig),
+			Requester:  claims.Email,
+			Reason:     body.Reason,
+			Duration:   metav1.Duration{Duration: duration},
+		},
+	}
+	ctx := c.Request.Context()
+	request, err = kubecfg.client.KubeconfigV1alpha1().AccessRequests(namespace).Create(ctx, request, metav1.CreateOptions{})
+	if err != nil {
+		logger.Errorf("Error creating access request: %s", err)
+		c.String(http.StatusInternalServerError, "Error creating access request")
+		return
+	}
+
+	request.Status.Phase = v1alpha1.AccessRequestPending
+	request, err = kubecfg.client.KubeconfigV1alpha1().AccessRequests(namespace).UpdateStatus(ctx, request, metav1.UpdateOptions{})
+	if err != nil {
+		logger.Errorf("Error updating access request status: %s", err)
+		c.String(http.StatusInternalServerError, "Error updating access request status")
+		return
+	}
+
+	logger.Infow("Access request created", "name", request.Name, "kubeconfig", kubeconfig.Name, "requester", claims.Email)
+	c.JSON(http.StatusCreated, gin.H{"name": request.Name})
+}
+
+func handleApproveAccessRequest(c *gin.Context) {
+	handleAccessDecision(c, v1alpha1.AccessRequestApproved)
+}
+
+func handleDenyAccessRequest(c *gin.Context) {
+	handleAccessDecision(c, v1alpha1.AccessRequestDenied)
+}
+
+// Approves or denies a pending AccessRequest. On approval, an AccessGrant owned by the
+// request is created and expires after the requested duration.
+func handleAccessDecision(c *gin.Context, phase v1alpha1.AccessRequestPhase) {
+	logger.Debugw("Entering handleAccessDecision", "phase", phase)
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	var body accessDecisionBody
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.String(http.StatusBadRequest, "Invalid decision: "+err.Error())
+			return
+		}
+	}
+
+	namespace := viper.GetString(podNamespaceKey)
+	ctx := c.Request.Context()
+	request, err := kubecfg.client.KubeconfigV1alpha1().AccessRequests(namespace).Get(ctx, c.Param("name"), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		c.String(http.StatusNotFound, "Access request not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting access request: %s", err)
+		c.String(http.StatusInternalServerError, "Error getting access request")
+		return
+	}
+
+	kubeconfig, err := getKubeconfig(request.Spec.Kubeconfig)
+	if err != nil {
+		logger.Errorf("Error getting kubeconfig of access request: %s", err)
+		c.String(http.StatusNotFound, "Kubeconfig not found")
+		return
+	}
+	if !canDecideAccessRequest(kubeconfig, request, claims) {
+		c.String(http.StatusForbidden, "You are not allowed to decide on this access request")
+		return
+	}
+	if accessRequestPhase(request) != v1alpha1.AccessRequestPending {
+		c.String(http.StatusConflict, "Access request is not pending")
+		return
+	}
+
+	err = decideAccessRequest(ctx, namespace, request, kubeconfig, phase, claims.Email, body.Message)
+	if apierrors.IsConflict(err) {
+		c.String(http.StatusConflict, "Access request was modified, try again")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error deciding access request: %s", err)
+		c.String(http.StatusInternalServerError, "Error deciding access request")
+		return
+	}
+
+	logger.Infow("Access request decided", "name", request.Name, "phase", phase, "approver", claims.Email)
+	c.Status(http.StatusNoContent)
+}
+
+// Records the decision on a pending request, then creates the AccessGrant of an approved request or
+// deletes the grant of a denied one. The status is updated first, with the resourceVersion of the
+// request the decision was made on, so that concurrent decisions conflict before any of them
+// touches the grant. The request is put back to pending when its grant cannot be created.
+func decideAccessRequest(ctx context.Context, namespace string, request *v1alpha1.AccessRequest, kubeconfig *v1alpha1.Kubeconfig,
+	phase v1alpha1.AccessRequestPhase, approver, message string) error {
+	now := metav1.Now()
+	decided := request.DeepCopy()
+	decided.Status.Phase = phase
+	decided.Status.DecidedBy = approver
+	decided.Status.DecidedAt = &now
+	decided.Status.Message = message
+	if phase == v1alpha1.AccessRequestApproved {
+		expiresAt := metav1.NewTime(now.Add(request.Spec.Duration.Duration))
+		decided.Status.ExpiresAt = &expiresAt
+	}
+
+	requests := kubecfg.client.KubeconfigV1alpha1().AccessRequests(namespace)
+	decided, err := requests.UpdateStatus(ctx, decided, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if phase != v1alpha1.AccessRequestApproved {
+		return deleteAccessGrant(ctx, namespace, decided)
+	}
+
+	grant := &v1alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   decided.Name,
+			Labels: map[string]string{kubeconfigLabel: kubeconfig.Name},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(decided, v1alpha1.SchemeGroupVersion.WithKind("AccessRequest")),
+			},
+		},
+		Spec: v1alpha1.AccessGrantSpec{
+			Kubeconfig: kubeconfigID(kubeconfig),
+			User:       decided.Spec.Requester,
+			ExpiresAt:  *decided.Status.ExpiresAt,
+			Reason:     decided.Spec.Reason,
+		},
+	}
+	if err := createOrUpdateAccessGrant(ctx, namespace, decided, grant); err != nil {
+		pending := decided.DeepCopy()
+		pending.Status = v1alpha1.AccessRequestStatus{Phase: v1alpha1.AccessRequestPending}
+		if _, revertErr := requests.UpdateStatus(ctx, pending, metav1.UpdateOptions{}); revertErr != nil {
+			logger.Errorf("Error putting access request %s back to pending: %s", decided.Name, revertErr)
+		}
+		return fmt.Errorf("creating access grant: %w", err)
+	}
+	return nil
+}
+
+// Creates the grant of an approved request. The grant is updated when it already exists, so that
+// the decision can be retried when a previous approval left its grant behind.
+func createOrUpdateAccessGrant(ctx context.Context, namespace string, request *v1alpha1.AccessRequest, grant *v1alpha1.AccessGrant) error {
+	grants := kubecfg.client.KubeconfigV1alpha1().AccessGrants(namespace)
+	_, err := grants.Create(ctx, grant, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, err := grants.Get(ctx, grant.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, request) {
+		return fmt.Errorf("access grant %s already exists and is not owned by the request", grant.Name)
+	}
+	updated := existing.DeepCopy()
+	updated.Labels = grant.Labels
+	updated.Spec = grant.Spec
+	_, err = grants.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// Deletes the grant a previous approval of the request may have left behind
+func deleteAccessGrant(ctx context.Context, namespace string, request *v1alpha1.AccessRequest) error {
+	grants := kubecfg.client.KubeconfigV1alpha1().AccessGrants(namespace)
+	grant, err := grants.Get(ctx, request.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(grant, request) {
+		return nil
+	}
+	err = grants.Delete(ctx, grant.Name, metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions(string(grant.UID))})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Returns true if the user is an approver of the Kubeconfig and not the requester
+func canDecideAccessRequest(kubeconfig *v1alpha1.Kubeconfig, request *v1alpha1.AccessRequest, claims EmailAndGroups) bool {
+	return request.Spec.Requester != claims.Email && matchWhitelist(kubeconfig.Spec.Approvers, claims)
+}
+
+func accessRequestPhase(request *v1alpha1.AccessRequest) v1alpha1.AccessRequestPhase {
+	if request.Status.Phase == "" {
+		return v1alpha1.AccessRequestPending
+	}
+	return request.Status.Phase
+}
+
+// Periodically deletes expired AccessGrants and marks approved AccessRequests as expired
+func runAccessExpiry(ctx context.Context) {
+	wait.UntilWithContext(ctx, expireAccess, viper.GetDuration(accessExpiryIntervalKey))
+}
+
+func expireAccess(ctx context.Context) {
+	namespace := viper.GetString(podNamespaceKey)
+	now := time.Now()
+
+	grants, err := kubecfg.grantLister.AccessGrants(namespace).List(labels.Everything())
+	if err != nil {
+		logger.Errorf("Error listing access grants: %s", err)
+		return
+	}
+	for _, grant := range grants {
+		if grant.Spec.ExpiresAt.After(now) {
+			continue
+		}
+		err := kubecfg.client.KubeconfigV1alpha1().AccessGrants(namespace).Delete(ctx, grant.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Errorf("Error deleting expired access grant %s: %s", grant.Name, err)
+			continue
+		}
+		logger.Infow("Access grant expired", "name", grant.Name, "kubeconfig", grant.Spec.Kubeconfig, "user", grant.Spec.User)
+	}
+
+	requests, err := kubecfg.requestLister.AccessRequests(namespace).List(labels.Everything())
+	if err != nil {
+		logger.Errorf("Error listing access requests: %s", err)
+		return
+	}
+	for _, request := range requests {
+		if request.Status.Phase != v1alpha1.AccessRequestApproved || request.Status.ExpiresAt == nil || request.Status.ExpiresAt.After(now) {
+			continue
+		}
+		expired := request.DeepCopy()
+		expired.Status.Phase = v1alpha1.AccessRequestExpired
+		_, err := kubecfg.client.KubeconfigV1alpha1().AccessRequests(namespace).UpdateStatus(ctx, expired, metav1.UpdateOptions{})
+		if err != nil {
+			logger.Errorf("Error expiring access request %s: %s", request.Name, err)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/fake"
+	listers "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Sets a fake client holding the requests and grants, and listers over them
+func setTestAccessClient(t *testing.T, requests []*v1alpha1.AccessRequest, grants []*v1alpha1.AccessGrant) *fake.Clientset {
+	t.Helper()
+	var objects []runtime.Object
+	requestIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, request := range requests {
+		objects = append(objects, request)
+		if err := requestIndexer.Add(request); err != nil {
+			t.Fatal(err)
+		}
+	}
+	grantIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, grant := range grants {
+		objects = append(objects, grant)
+		if err := grantIndexer.Add(grant); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := fake.NewSimpleClientset(objects...)
+	previousClient, previousRequestLister, previousGrantLister := kubecfg.client, kubecfg.requestLister, kubecfg.grantLister
+	kubecfg.client = client
+	kubecfg.requestLister = listers.NewAccessRequestLister(requestIndexer)
+	kubecfg.grantLister = listers.NewAccessGrantLister(grantIndexer)
+	t.Cleanup(func() {
+		kubecfg.client, kubecfg.requestLister, kubecfg.grantLister = previousClient, previousRequestLister, previousGrantLister
+	})
+	return client
+}
+
+func testAccessRequest(name string, phase v1alpha1.AccessRequestPhase) *v1alpha1.AccessRequest {
+	return &v1alpha1.AccessRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: name, UID: "request-uid"},
+		Spec: v1alpha1.AccessRequestSpec{
+			Kubeconfig: "prod",
+			Requester:  "jane@example.com",
+			Reason:     "incident",
+			Duration:   metav1.Duration{Duration: time.Hour},
+		},
+		Status: v1alpha1.AccessRequestStatus{Phase: phase},
+	}
+}
+
+func testAccessGrant(name string, owner *v1alpha1.AccessRequest, expiresAt time.Time) *v1alpha1.AccessGrant {
+	grant := &v1alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: name},
+		Spec:       v1alpha1.AccessGrantSpec{Kubeconfig: "prod", User: "jane@example.com", ExpiresAt: metav1.NewTime(expiresAt)},
+	}
+	if owner != nil {
+		grant.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, v1alpha1.SchemeGroupVersion.WithKind("AccessRequest"))}
+	}
+	return grant
+}
+
+func TestCanDecideAccessRequest(t *testing.T) {
+	request := testAccessRequest("prod-abcde", v1alpha1.AccessRequestPending)
+
+	tests := []struct {
+		name      string
+		approvers *v1alpha1.Whitelist
+		claims    EmailAndGroups
+		want      bool
+	}{
+		{
+			name:      "approver user",
+			approvers: &v1alpha1.Whitelist{Users: []string{"john@example.com"}},
+			claims:    EmailAndGroups{Email: "john@example.com"},
+			want:      true,
+		},
+		{
+			name:      "approver group",
+			approvers: &v1alpha1.Whitelist{Groups: []string{"platform-team"}},
+			claims:    EmailAndGroups{Email: "john@example.com", Groups: []string{"platform-team"}},
+			want:      true,
+		},
+		{
+			name:      "requester in the approvers",
+			approvers: &v1alpha1.Whitelist{Groups: []string{"platform-team"}},
+			claims:    EmailAndGroups{Email: "jane@example.com", Groups: []string{"platform-team"}},
+			want:      false,
+		},
+		{
+			name:      "not an approver",
+			approvers: &v1alpha1.Whitelist{Groups: []string{"platform-team"}},
+			claims:    EmailAndGroups{Email: "john@example.com", Groups: []string{"developers"}},
+			want:      false,
+		},
+		{
+			name:      "no approvers",
+			approvers: nil,
+			claims:    EmailAndGroups{Email: "john@example.com"},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfig := &v1alpha1.Kubeconfig{Spec: v1alpha1.KubeconfigSpec{Approvers: tt.approvers}}
+			if got := canDecideAccessRequest(kubeconfig, request, tt.claims); got != tt.want {
+				t.Errorf("canDecideAccessRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecideAccessRequest(t *testing.T) {
+	kubeconfig := &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+	request := testAccessRequest("prod-abcde", v1alpha1.AccessRequestPending)
+	otherRequest := testAccessRequest("prod-abcde", v1alpha1.AccessRequestPending)
+	otherRequest.UID = "other-uid"
+
+	tests := []struct {
+		name      string
+		phase     v1alpha1.AccessRequestPhase
+		grants    []*v1alpha1.AccessGrant
+		reactor   func(client *fake.Clientset)
+		wantErr   func(error) bool
+		wantPhase v1alpha1.AccessRequestPhase
+		wantGrant bool
+	}{
+		{
+			name:      "approve",
+			phase:     v1alpha1.AccessRequestApproved,
+			wantPhase: v1alpha1.AccessRequestApproved,
+			wantGrant: true,
+		},
+		{
+			name:      "approve with a grant left behind",
+			phase:     v1alpha1.AccessRequestApproved,
+			grants:    []*v1alpha1.AccessGrant{testAccessGrant("prod-abcde", request, time.Now().Add(-time.Hour))},
+			wantPhase: v1alpha1.AccessRequestApproved,
+			wantGrant: true,
+		},
+		{
+			name:      "deny",
+			phase:     v1alpha1.AccessRequestDenied,
+			wantPhase: v1alpha1.AccessRequestDenied,
+		},
+		{
+			name:      "deny deletes a grant left behind",
+			phase:     v1alpha1.AccessRequestDenied,
+			grants:    []*v1alpha1.AccessGrant{testAccessGrant("prod-abcde", request, time.Now().Add(time.Hour))},
+			wantPhase: v1alpha1.AccessRequestDenied,
+		},
+		{
+			name:      "deny keeps a grant of another request",
+			phase:     v1alpha1.AccessRequestDenied,
+			grants:    []*v1alpha1.AccessGrant{testAccessGrant("prod-abcde", otherRequest, time.Now().Add(time.Hour))},
+			wantPhase: v1alpha1.AccessRequestDenied,
+			wantGrant: true,
+		},
+		{
+			name:  "concurrent decision",
+			phase: v1alpha1.AccessRequestApproved,
+			reactor: func(client *fake.Clientset) {
+				client.PrependReactor("update", "accessrequests", func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewConflict(v1alpha1.Resource("accessrequests"), "prod-abcde", errors.New("modified"))
+				})
+			},
+			wantErr:   apierrors.IsConflict,
+			wantPhase: v1alpha1.AccessRequestPending,
+		},
+		{
+			name:  "concurrent decision leaves the grant of the other one",
+			phase: v1alpha1.AccessRequestDenied,
+			grants: []*v1alpha1.AccessGrant{
+				testAccessGrant("prod-abcde", request, time.Now().Add(time.Hour)),
+			},
+			reactor: func(client *fake.Clientset) {
+				client.PrependReactor("update", "accessrequests", func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewConflict(v1alpha1.Resource("accessrequests"), "prod-abcde", errors.New("modified"))
+				})
+			},
+			wantErr:   apierrors.IsConflict,
+			wantPhase: v1alpha1.AccessRequestPending,
+			wantGrant: true,
+		},
+		{
+			name:  "grant creation failure puts the request back to pending",
+			phase: v1alpha1.AccessRequestApproved,
+			reactor: func(client *fake.Clientset) {
+				client.PrependReactor("create", "accessgrants", func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewInternalError(errors.New("unavailable"))
+				})
+			},
+			wantErr:   func(err error) bool { return err != nil },
+			wantPhase: v1alpha1.AccessRequestPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := setTestAccessClient(t, []*v1alpha1.AccessRequest{request.DeepCopy()}, tt.grants)
+			if tt.reactor != nil {
+				tt.reactor(client)
+			}
+
+			err := decideAccessRequest(context.Background(), "kubebrowser", request.DeepCopy(), kubeconfig, tt.phase, "john@example.com", "ok")
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("decideAccessRequest() error = %v", err)
+			}
+			if tt.wantErr != nil && !tt.wantErr(err) {
+				t.Fatalf("decideAccessRequest() error = %v, want a matching error", err)
+			}
+
+			stored, err := client.KubeconfigV1alpha1().AccessRequests("kubebrowser").Get(context.Background(), "prod-abcde", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := accessRequestPhase(stored); got != tt.wantPhase {
+				t.Errorf("phase = %q, want %q", got, tt.wantPhase)
+			}
+			if tt.wantPhase == v1alpha1.AccessRequestPending && (stored.Status.DecidedBy != "" || stored.Status.ExpiresAt != nil) {
+				t.Errorf("status = %+v, want no decision", stored.Status)
+			}
+
+			grant, err := client.KubeconfigV1alpha1().AccessGrants("kubebrowser").Get(context.Background(), "prod-abcde", metav1.GetOptions{})
+			if gotGrant := err == nil; gotGrant != tt.wantGrant {
+				t.Fatalf("grant exists = %v, want %v (error %v)", gotGrant, tt.wantGrant, err)
+			}
+			if tt.wantGrant && tt.wantPhase == v1alpha1.AccessRequestApproved {
+				if !grant.Spec.ExpiresAt.Equal(stored.Status.ExpiresAt) || grant.Spec.User != "jane@example.com" || !metav1.IsControlledBy(grant, request) {
+					t.Errorf("grant = %+v, want a grant of the request until %s", grant, stored.Status.ExpiresAt)
+				}
+			}
+		})
+	}
+}
+
+func TestExpireAccess(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	t.Cleanup(func() { viper.Set(podNamespaceKey, "") })
+
+	now := time.Now()
+	past, future := metav1.NewTime(now.Add(-time.Minute)), metav1.NewTime(now.Add(time.Hour))
+	request := func(name string, phase v1alpha1.AccessRequestPhase, expiresAt *metav1.Time) *v1alpha1.AccessRequest {
+		request := testAccessRequest(name, phase)
+		request.Status.ExpiresAt = expiresAt
+		return request
+	}
+	client := setTestAccessClient(t,
+		[]*v1alpha1.AccessRequest{
+			request("expired", v1alpha1.AccessRequestApproved, &past),
+			request("active", v1alpha1.AccessRequestApproved, &future),
+			request("denied", v1alpha1.AccessRequestDenied, nil),
+			request("pending", v1alpha1.AccessRequestPending, nil),
+		},
+		[]*v1alpha1.AccessGrant{
+			testAccessGrant("expired", nil, past.Time),
+			testAccessGrant("active", nil, future.Time),
+		},
+	)
+
+	expireAccess(context.Background())
+
+	wantPhases := map[string]v1alpha1.AccessRequestPhase{
+		"expired": v1alpha1.AccessRequestExpired,
+		"active":  v1alpha1.AccessRequestApproved,
+		"denied":  v1alpha1.AccessRequestDenied,
+		"pending": v1alpha1.AccessRequestPending,
+	}
+	for name, want := range wantPhases {
+		stored, err := client.KubeconfigV1alpha1().AccessRequests("kubebrowser").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := accessRequestPhase(stored); got != want {
+			t.Errorf("phase of %s = %q, want %q", name, got, want)
+		}
+	}
+
+	if _, err := client.KubeconfigV1alpha1().AccessGrants("kubebrowser").Get(context.Background(), "expired", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expired grant error = %v, want NotFound", err)
+	}
+	if _, err := client.KubeconfigV1alpha1().AccessGrants("kubebrowser").Get(context.Background(), "active", metav1.GetOptions{}); err != nil {
+		t.Errorf("active grant error = %v, want it kept", err)
+	}
+}
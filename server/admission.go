@@ -0,0 +1,135 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// Claims checked by the admission policy
+type AdmissionClaims struct {
+	Email         string
+	EmailVerified *bool `json:"email_verified"`
+	Groups        []string
+}
+
+var forbiddenPage = template.Must(template.New("forbidden").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Access denied - Kubebrowser</title>
+  <style>
+    body { font-family: sans-serif; display: flex; justify-content: center; margin-top: 10vh; color: #1f2937; }
+    main { max-width: 32rem; text-align: center; }
+  </style>
+</head>
+<body>
+  <main>
+    <h1>Access denied</h1>
+    <p>Sorry {{ .Email }}, you are not allowed to use Kubebrowser.</p>
+    <p>{{ .Reason }}</p>
+    <p>If you think this is a mistake, please contact your administrator.</p>
+  </main>
+</body>
+</html>
+`))
+
+// Splits a comma separated configuration value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Global admission policy applied to every login
+type AdmissionPolicy struct {
+	RequireEmailVerified bool
+	// Lowercase email domains, any domain if empty
+	AllowedEmailDomains []string
+	// Groups the user must be a member of one of, by ID or alias display name, any group if empty
+	RequiredGroups []string
+}
+
+var admissionPolicy AdmissionPolicy
+
+// Loads the admission policy from the configuration
+func loadAdmissionPolicy() AdmissionPolicy {
+	domains := splitList(viper.GetString(allowedEmailDomainsKey))
+	for i, domain := range domains {
+		domains[i] = strings.ToLower(domain)
+	}
+	return AdmissionPolicy{
+		RequireEmailVerified: viper.GetBool(requireEmailVerifiedKey),
+		AllowedEmailDomains:  domains,
+		RequiredGroups:       splitList(viper.GetString(requiredGroupsKey)),
+	}
+}
+
+// Returns why the user is not admitted by the policy, or an empty string if they are
+func (policy AdmissionPolicy) admit(claims AdmissionClaims, aliases map[string]string) string {
+	if policy.RequireEmailVerified && (claims.EmailVerified == nil || !*claims.EmailVerified) {
+		return "Your email address is not verified."
+	}
+
+	if len(policy.AllowedEmailDomains) > 0 {
+		_, domain, _ := strings.Cut(claims.Email, "@")
+		if !slices.Contains(policy.AllowedEmailDomains, strings.ToLower(domain)) {
+			return "Your email domain is not allowed."
+		}
+	}
+
+	if len(policy.RequiredGroups) > 0 {
+		groups := resolveGroupAliases(EmailAndGroups{Email: claims.Email, Groups: claims.Groups}, aliases)
+		if !slices.ContainsFunc(policy.RequiredGroups, groups.memberOf) {
+			return "You are not a member of any allowed group."
+		}
+	}
+
+	return ""
+}
+
+// Applies the global admission policy to the ID token. When the user is rejected, an audit entry
+// is logged, a forbidden page is rendered and false is returned.
+func checkAdmission(c *gin.Context, idToken *oidc.IDToken) bool {
+	var claims AdmissionClaims
+	if err := idToken.Claims(&claims); err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return false
+	}
+
+	aliases, err := groupAliases()
+	if err != nil {
+		logger.Errorf("Error listing group aliases: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing group aliases")
+		return false
+	}
+
+	reason := admissionPolicy.admit(claims, aliases)
+	if reason == "" {
+		return true
+	}
+
+	audit("Login rejected by admission policy", "email", claims.Email, "subject", idToken.Subject, "reason", reason, "path", c.Request.URL.Path)
+
+	if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+		c.String(http.StatusForbidden, reason)
+		return false
+	}
+
+	c.Status(http.StatusForbidden)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	if err := forbiddenPage.Execute(c.Writer, gin.H{"Email": claims.Email, "Reason": reason}); err != nil {
+		logger.Errorf("Cannot render forbidden page: %s", err)
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/viper"
+	"k8s.io/utils/ptr"
+)
+
+func TestLoadAdmissionPolicy(t *testing.T) {
+	viper.Set(allowedEmailDomainsKey, " Example.com, CORP.example.org ,")
+	viper.Set(requiredGroupsKey, "platform-team")
+	t.Cleanup(func() {
+		viper.Set(allowedEmailDomainsKey, "")
+		viper.Set(requiredGroupsKey, "")
+	})
+
+	policy := loadAdmissionPolicy()
+	if want := []string{"example.com", "corp.example.org"}; !slices.Equal(policy.AllowedEmailDomains, want) {
+		t.Errorf("AllowedEmailDomains = %v, want %v", policy.AllowedEmailDomains, want)
+	}
+	if want := []string{"platform-team"}; !slices.Equal(policy.RequiredGroups, want) {
+		t.Errorf("RequiredGroups = %v, want %v", policy.RequiredGroups, want)
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	aliases := map[string]string{"5091ba02": "platform-team"}
+
+	tests := []struct {
+		name   string
+		policy AdmissionPolicy
+		claims AdmissionClaims
+		want   string
+	}{
+		{
+			name:   "empty policy",
+			policy: AdmissionPolicy{},
+			claims: AdmissionClaims{Email: "jane@example.com"},
+			want:   "",
+		},
+		{
+			name:   "verified email",
+			policy: AdmissionPolicy{RequireEmailVerified: true},
+			claims: AdmissionClaims{Email: "jane@example.com", EmailVerified: ptr.To(true)},
+			want:   "",
+		},
+		{
+			name:   "unverified email",
+			policy: AdmissionPolicy{RequireEmailVerified: true},
+			claims: AdmissionClaims{Email: "jane@example.com", EmailVerified: ptr.To(false)},
+			want:   "Your email address is not verified.",
+		},
+		{
+			name:   "missing email_verified claim",
+			policy: AdmissionPolicy{RequireEmailVerified: true},
+			claims: AdmissionClaims{Email: "jane@example.com"},
+			want:   "Your email address is not verified.",
+		},
+		{
+			name:   "allowed domain in another case",
+			policy: AdmissionPolicy{AllowedEmailDomains: []string{"example.com"}},
+			claims: AdmissionClaims{Email: "jane@Example.COM"},
+			want:   "",
+		},
+		{
+			name:   "other domain",
+			policy: AdmissionPolicy{AllowedEmailDomains: []string{"example.com"}},
+			claims: AdmissionClaims{Email: "jane@example.org"},
+			want:   "Your email domain is not allowed.",
+		},
+		{
+			name:   "subdomain",
+			policy: AdmissionPolicy{AllowedEmailDomains: []string{"example.com"}},
+			claims: AdmissionClaims{Email: "jane@evil.example.com"},
+			want:   "Your email domain is not allowed.",
+		},
+		{
+			name:   "email without domain",
+			policy: AdmissionPolicy{AllowedEmailDomains: []string{"example.com"}},
+			claims: AdmissionClaims{Email: "jane"},
+			want:   "Your email domain is not allowed.",
+		},
+		{
+			name:   "required group by ID",
+			policy: AdmissionPolicy{RequiredGroups: []string{"5091ba02"}},
+			claims: AdmissionClaims{Email: "jane@example.com", Groups: []string{"5091ba02"}},
+			want:   "",
+		},
+		{
+			name:   "required group by alias",
+			policy: AdmissionPolicy{RequiredGroups: []string{"developers", "platform-team"}},
+			claims: AdmissionClaims{Email: "jane@example.com", Groups: []string{"5091ba02"}},
+			want:   "",
+		},
+		{
+			name:   "not in required groups",
+			policy: AdmissionPolicy{RequiredGroups: []string{"platform-team"}},
+			claims: AdmissionClaims{Email: "jane@example.com", Groups: []string{"developers"}},
+			want:   "You are not a member of any allowed group.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.admit(tt.claims, aliases); got != tt.want {
+				t.Errorf("admit() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
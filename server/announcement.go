@@ -0,0 +1,131 @@
+package main
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Announcement as sent to the UI
+type announcementView struct {
+	Name      string                        `json:"name"`
+	Severity  v1alpha1.AnnouncementSeverity `json:"severity"`
+	Body      string                        `json:"body"`
+	StartTime *metav1.Time                  `json:"startTime,omitempty"`
+	EndTime   *metav1.Time                  `json:"endTime,omitempty"`
+}
+
+// Order of the severities, most severe first
+var announcementSeverityOrder = map[v1alpha1.AnnouncementSeverity]int{
+	v1alpha1.AnnouncementCritical: 0,
+	v1alpha1.AnnouncementWarning:  1,
+	v1alpha1.AnnouncementInfo:     2,
+}
+
+// Returns the severity of an Announcement, info if unset
+func announcementSeverity(announcement *v1alpha1.Announcement) v1alpha1.AnnouncementSeverity {
+	if announcement.Spec.Severity == "" {
+		return v1alpha1.AnnouncementInfo
+	}
+	return announcement.Spec.Severity
+}
+
+// Returns whether the time is within the time window of an Announcement
+func announcementActive(announcement *v1alpha1.Announcement, now time.Time) bool {
+	if start := announcement.Spec.StartTime; start != nil && now.Before(start.Time) {
+		return false
+	}
+	if end := announcement.Spec.EndTime; end != nil && !now.Before(end.Time) {
+		return false
+	}
+	return true
+}
+
+// Returns whether an Announcement is shown to the user, who can access the Kubeconfigs
+func announcementAudienceMatches(announcement *v1alpha1.Announcement, claims EmailAndGroups, kubeconfigs []*v1alpha1.Kubeconfig) (bool, error) {
+	audience := announcement.Spec.Audience
+	if audience == nil {
+		return true, nil
+	}
+	if matchWhitelist(&v1alpha1.Whitelist{Users: audience.Users, Groups: audience.Groups}, claims) {
+		return true, nil
+	}
+	if audience.KubeconfigSelector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(audience.KubeconfigSelector)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(kubeconfigs, func(kubeconfig *v1alpha1.Kubeconfig) bool {
+		return selector.Matches(labels.Set(kubeconfig.Labels))
+	}), nil
+}
+
+// Lists the current Announcements shown to the user, most severe first
+func handleGetAnnouncements(c *gin.Context) {
+	logger.Debug("Entering handleGetAnnouncements")
+
+	views := make([]announcementView, 0)
+	if !kubecfg.HasCluster() {
+		c.JSON(http.StatusOK, views)
+		return
+	}
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+	kubeconfigs, err := authorizedKubeconfigs(c)
+	if err != nil {
+		logger.Errorf("Error preparing kubeconfigs: %s", err)
+		c.String(http.StatusInternalServerError, "Error preparing kubeconfigs")
+		return
+	}
+	announcements, err := kubecfg.announcementLister.Announcements(viper.GetString(podNamespaceKey)).List(labels.Everything())
+	if err != nil {
+		logger.Errorf("Error listing announcements: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing announcements")
+		return
+	}
+
+	now := time.Now()
+	for _, announcement := range announcements {
+		if !announcementActive(announcement, now) {
+			continue
+		}
+		matches, err := announcementAudienceMatches(announcement, claims, kubeconfigs)
+		if err != nil {
+			logger.Warnw("Skipping announcement with invalid kubeconfig selector", "name", announcement.Name, "error", err)
+			continue
+		}
+		if !matches {
+			continue
+		}
+		views = append(views, announcementView{
+			Name:      announcement.Name,
+			Severity:  announcementSeverity(announcement),
+			Body:      announcement.Spec.Body,
+			StartTime: announcement.Spec.StartTime,
+			EndTime:   announcement.Spec.EndTime,
+		})
+	}
+
+	slices.SortFunc(views, func(a, b announcementView) int {
+		if order := cmp.Compare(announcementSeverityOrder[a.Severity], announcementSeverityOrder[b.Severity]); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	c.JSON(http.StatusOK, views)
+}
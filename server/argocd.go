@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// Prefix of the IDs of Kubeconfigs imported from Argo CD, followed by the Secret name
+	argoCDIDPrefix = "argocd:"
+
+	// Label of the Secrets declaring an Argo CD cluster
+	argoCDSecretTypeLabel = "argocd.argoproj.io/secret-type=cluster"
+
+	// Server of the cluster Argo CD runs in, which users cannot reach
+	argoCDInClusterServer = "https://kubernetes.default.svc"
+)
+
+// Subset of the config key of an Argo CD cluster Secret. The bearer token and client
+// certificates are never read.
+type argoCDClusterConfig struct {
+	TLSClientConfig struct {
+		Insecure   bool   `json:"insecure"`
+		ServerName string `json:"serverName"`
+		CAData     []byte `json:"caData"`
+	} `json:"tlsClientConfig"`
+}
+
+// Watches the Argo CD cluster Secrets of the configured namespace. Returns the functions telling
+// whether the informers are synced.
+func (k *Kubecfg) initArgoCD(ctx context.Context) ([]cache.InformerSynced, error) {
+	selector := argoCDSecretTypeLabel
+	if extra := viper.GetString(argoCDSecretSelectorKey); extra != "" {
+		selector += "," + extra
+	}
+	if _, err := labels.Parse(selector); err != nil {
+		return nil, err
+	}
+
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		k.kubeClient,
+		viper.GetDuration(informerResyncKey),
+		kubeinformers.WithNamespace(viper.GetString(argoCDNamespaceKey)),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		}),
+	)
+	secretInformer := informerFactory.Core().V1().Secrets()
+	if err := secretInformer.Informer().SetTransform(stripArgoCDSecret); err != nil {
+		return nil, err
+	}
+	k.argoCDSecretLister = secretInformer.Lister()
+	if err := publishCatalogChanges(secretInformer.Informer(), nil); err != nil {
+		return nil, err
+	}
+
+	informerFactory.Start(ctx.Done())
+
+	return []cache.InformerSynced{secretInformer.Informer().HasSynced}, nil
+}
+
+// Keeps only the name, server and TLS settings of the Argo CD cluster Secrets in the cache, without
+// their bearer token and client certificates
+func stripArgoCDSecret(obj any) (any, error) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return obj, nil
+	}
+
+	stripped := &corev1.Secret{ObjectMeta: strippedObjectMeta(secret.ObjectMeta), Type: secret.Type, Data: map[string][]byte{}}
+	for _, key := range []string{"name", "server"} {
+		if value, ok := secret.Data[key]; ok {
+			stripped.Data[key] = value
+		}
+	}
+	if raw, ok := secret.Data["config"]; ok {
+		var config argoCDClusterConfig
+		if err := json.Unmarshal(raw, &config); err != nil {
+			// Keep the Secret invalid rather than caching its credentials
+			stripped.Data["config"] = []byte("invalid")
+		} else if stripped.Data["config"], err = json.Marshal(config); err != nil {
+			return nil, err
+		}
+	}
+	return stripped, nil
+}
+
+// Lists the Kubeconfigs of the Argo CD clusters
+func listArgoCDKubeconfigs() ([]*v1alpha1.Kubeconfig, error) {
+	if kubecfg.argoCDSecretLister == nil {
+		return nil, nil
+	}
+
+	secrets, err := kubecfg.argoCDSecretLister.Secrets(viper.GetString(argoCDNamespaceKey)).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigs := make([]*v1alpha1.Kubeconfig, 0, len(secrets))
+	for _, secret := range secrets {
+		kubeconfig, err := argoCDKubeconfig(secret)
+		if err != nil {
+			logger.Debugw("Skipping Argo CD cluster", "secret", secret.Name, "error", err)
+			continue
+		}
+		kubeconfigs = append(kubeconfigs, kubeconfig)
+	}
+	return kubeconfigs, nil
+}
+
+// Returns the Kubeconfig of an Argo CD cluster from the Secret name part of its ID
+func getArgoCDKubeconfig(name string) (*v1alpha1.Kubeconfig, error) {
+	if kubecfg.argoCDSecretLister == nil {
+		return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+
+	secret, err := kubecfg.argoCDSecretLister.Secrets(viper.GetString(argoCDNamespaceKey)).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return argoCDKubeconfig(secret)
+}
+
+// Builds a Kubeconfig from the server and CA data of an Argo CD cluster Secret
+func argoCDKubeconfig(secret *corev1.Secret) (*v1alpha1.Kubeconfig, error) {
+	server := string(secret.Data["server"])
+	if server == "" {
+		return nil, fmt.Errorf("no server in secret")
+	}
+	if server == argoCDInClusterServer {
+		return nil, fmt.Errorf("in-cluster server is not reachable by users")
+	}
+	if _, err := url.Parse(server); err != nil {
+		return nil, err
+	}
+
+	var config argoCDClusterConfig
+	if raw, ok := secret.Data["config"]; ok {
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return nil, fmt.Errorf("invalid config in secret: %w", err)
+		}
+	}
+
+	name := string(secret.Data["name"])
+	if name == "" {
+		name = secret.Name
+	}
+	details := v1alpha1.Details{
+		Server:                server,
+		InsecureSkipTLSVerify: config.TLSClientConfig.Insecure,
+		TLSServerName:         config.TLSClientConfig.ServerName,
+	}
+	if len(config.TLSClientConfig.CAData) > 0 {
+		details.CertificateAuthorityData = base64.StdEncoding.EncodeToString(config.TLSClientConfig.CAData)
+	}
+	data := &v1alpha1.KubeconfigData{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		Clusters:       []v1alpha1.Cluster{{Name: name, Cluster: details}},
+		Contexts:       []v1alpha1.Context{{Name: name, Context: v1alpha1.ContextSpec{Cluster: name}}},
+		CurrentContext: name,
+	}
+
+	return &v1alpha1.Kubeconfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			UID:         secret.UID,
+			Labels:      secret.Labels,
+			Annotations: kubebrowserAnnotations(secret.Annotations),
+		},
+		Spec: annotatedKubeconfigSpec(secret.Annotations, name, data),
+	}, nil
+}
+
+// Returns whether the Kubeconfig is imported from an Argo CD cluster Secret
+func isArgoCDKubeconfig(kubeconfig *v1alpha1.Kubeconfig) bool {
+	return kubeconfig.APIVersion == corev1.SchemeGroupVersion.String() && kubeconfig.Kind == "Secret"
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/base64"
+	"maps"
+	"strconv"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func argoCDSecret(annotations map[string]string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "argocd", Name: "cluster-prod", Annotations: annotations},
+		Data:       map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+func TestStripArgoCDSecret(t *testing.T) {
+	secret := argoCDSecret(nil, map[string]string{
+		"name":   "prod",
+		"server": "https://prod.example.com",
+		"config": `{"bearerToken":"secret-token","tlsClientConfig":{"serverName":"prod.internal","caData":"Y2E=","certData":"Y2VydA==","keyData":"a2V5"}}`,
+	})
+
+	obj, err := stripArgoCDSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stripped := obj.(*corev1.Secret)
+	config := string(stripped.Data["config"])
+	for _, credential := range []string{"bearerToken", "secret-token", "certData", "keyData"} {
+		if strings.Contains(config, credential) {
+			t.Errorf("config %s still holds %s", config, credential)
+		}
+	}
+
+	kubeconfig, err := argoCDKubeconfig(stripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	details := kubeconfig.Spec.Kubeconfig.Clusters[0].Cluster
+	if details.TLSServerName != "prod.internal" || details.CertificateAuthorityData != base64.StdEncoding.EncodeToString([]byte("ca")) {
+		t.Errorf("cluster = %+v, want the TLS settings of the Secret", details)
+	}
+
+	invalid := argoCDSecret(nil, map[string]string{"server": "https://prod.example.com", "config": `{"bearerToken":`})
+	obj, err = stripArgoCDSecret(invalid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := argoCDKubeconfig(obj.(*corev1.Secret)); err == nil {
+		t.Error("argoCDKubeconfig() of an invalid config succeeded")
+	}
+}
+
+func TestStripArgoCDSecretLastAppliedConfiguration(t *testing.T) {
+	config := `{"bearerToken":"secret-token","tlsClientConfig":{"keyData":"a2V5"}}`
+	secret := argoCDSecret(map[string]string{
+		corev1.LastAppliedConfigAnnotation: `{"stringData":{"config":` + strconv.Quote(config) + `}}`,
+		publicAnnotation:                   "true",
+		"argocd.argoproj.io/note":          "managed by argo",
+	}, map[string]string{"server": "https://prod.example.com", "config": config})
+
+	obj, err := stripArgoCDSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stripped := obj.(*corev1.Secret)
+	if _, ok := stripped.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		t.Errorf("Annotations = %v, want no last applied configuration", stripped.Annotations)
+	}
+	if secret.Annotations[corev1.LastAppliedConfigAnnotation] == "" {
+		t.Error("the informed object was modified")
+	}
+
+	kubeconfig, err := argoCDKubeconfig(stripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{publicAnnotation: "true"}
+	if !maps.Equal(kubeconfig.Annotations, want) {
+		t.Errorf("Kubeconfig annotations = %v, want %v", kubeconfig.Annotations, want)
+	}
+	if kubeconfig.Spec.Whitelist != nil {
+		t.Errorf("Whitelist = %+v, want a public Kubeconfig", kubeconfig.Spec.Whitelist)
+	}
+}
+
+func TestArgoCDKubeconfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		secret        *corev1.Secret
+		wantErr       bool
+		wantName      string
+		wantWhitelist *v1alpha1.Whitelist
+	}{
+		{
+			name:          "unannotated cluster is hidden",
+			secret:        argoCDSecret(nil, map[string]string{"name": "prod", "server": "https://prod.example.com"}),
+			wantName:      "prod",
+			wantWhitelist: &v1alpha1.Whitelist{},
+		},
+		{
+			name:          "public cluster",
+			secret:        argoCDSecret(map[string]string{publicAnnotation: "true"}, map[string]string{"server": "https://prod.example.com"}),
+			wantName:      "cluster-prod",
+			wantWhitelist: nil,
+		},
+		{
+			name:          "allowed groups",
+			secret:        argoCDSecret(map[string]string{allowedGroupsAnnotation: "platform-team"}, map[string]string{"name": "prod", "server": "https://prod.example.com"}),
+			wantName:      "prod",
+			wantWhitelist: &v1alpha1.Whitelist{Groups: []string{"platform-team"}},
+		},
+		{
+			name:    "in-cluster server",
+			secret:  argoCDSecret(nil, map[string]string{"server": argoCDInClusterServer}),
+			wantErr: true,
+		},
+		{
+			name:    "no server",
+			secret:  argoCDSecret(nil, map[string]string{"name": "prod"}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfig, err := argoCDKubeconfig(tt.secret)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("argoCDKubeconfig() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kubeconfig.Spec.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", kubeconfig.Spec.Name, tt.wantName)
+			}
+			if !equality.Semantic.DeepEqual(kubeconfig.Spec.Whitelist, tt.wantWhitelist) {
+				t.Errorf("Whitelist = %+v, want %+v", kubeconfig.Spec.Whitelist, tt.wantWhitelist)
+			}
+			if !isArgoCDKubeconfig(kubeconfig) {
+				t.Error("isArgoCDKubeconfig() = false")
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// Kubeconfigs with this label set to "allowed" accept break-glass access
+	breakGlassLabel   = "breakglass"
+	breakGlassAllowed = "allowed"
+
+	// Label put on AccessGrants created through break-glass
+	breakGlassGrantLabel = "kubebrowser.io/breakglass"
+)
+
+type breakGlassBody struct {
+	Kubeconfig    string `json:"kubeconfig" binding:"required"`
+	Justification string `json:"justification" binding:"required"`
+	TicketID      string `json:"ticketID" binding:"required"`
+	Duration      string `json:"duration"`
+}
+
+// breakGlassEvent is the payload sent to the break-glass webhook
+type breakGlassEvent struct {
+	Kubeconfig    string    `json:"kubeconfig"`
+	User          string    `json:"user"`
+	Justification string    `json:"justification"`
+	TicketID      string    `json:"ticketID"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// Lets a member of the break-glass group grant themselves a temporary access to a Kubeconfig
+// labeled for break-glass
+func handleCreateBreakGlassGrant(c *gin.Context) {
+	logger.Debug("Entering handleCreateBreakGlassGrant")
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	breakGlassGroup := viper.GetString(breakGlassGroupKey)
+	if breakGlassGroup == "" || !claims.memberOf(breakGlassGroup) {
+		c.String(http.StatusForbidden, "You are not allowed to use break-glass access")
+		return
+	}
+
+	var body breakGlassBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.String(http.StatusBadRequest, "Invalid break-glass request: "+err.Error())
+		return
+	}
+
+	duration := viper.GetDuration(breakGlassDurationKey)
+	if body.Duration != "" {
+		d, err := time.ParseDuration(body.Duration)
+		if err != nil || d <= 0 || d > duration {
+			c.String(http.StatusBadRequest, "Invalid duration, maximum is "+duration.String())
+			return
+		}
+		duration = d
+	}
+
+	namespace := viper.GetString(podNamespaceKey)
+	kubeconfig, err := getKubeconfig(body.Kubeconfig)
+	if apierrors.IsNotFound(err) {
+		c.String(http.StatusNotFound, "Kubeconfig not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting kubeconfig: %s", err)
+		c.String(http.StatusInternalServerError, "Error getting kubeconfig")
+		return
+	}
+	if kubeconfig.Labels[breakGlassLabel] != breakGlassAllowed || kubeconfigDisabled(kubeconfig) {
+		c.String(http.StatusForbidden, "Kubeconfig does not allow break-glass access")
+		return
+	}
+
+	expiresAt := metav1.NewTime(time.Now().Add(duration))
+	grant := &v1alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: kubeconfig.Name + "-breakglass-",
+			Labels: map[string]string{
+				kubeconfigLabel:      kubeconfig.Name,
+				breakGlassGrantLabel: "true",
+			},
+		},
+		Spec: v1alpha1.AccessGrantSpec{
+			Kubeconfig: kubeconfigID(kubeconfig),
+			User:       claims.Email,
+			ExpiresAt:  expiresAt,
+			Reason:     body.Justification,
+			BreakGlass: true,
+			TicketID:   body.TicketID,
+		},
+	}
+	grant, err = kubecfg.client.KubeconfigV1alpha1().AccessGrants(namespace).Create(c.Request.Context(), grant, metav1.CreateOptions{})
+	if err != nil {
+		logger.Errorf("Error creating break-glass grant: %s", err)
+		c.String(http.StatusInternalServerError, "Error creating break-glass grant")
+		return
+	}
+
+	logger.Warnw("Break-glass access granted", "kubeconfig", kubeconfig.Name, "user", claims.Email, "ticket", body.TicketID, "expiresAt", expiresAt)
+	recordBreakGlassEvent(kubeconfig, claims.Email, expiresAt, body)
+
+	go notifyBreakGlass(context.WithoutCancel(c.Request.Context()), breakGlassEvent{
+		Kubeconfig:    kubeconfigID(kubeconfig),
+		User:          claims.Email,
+		Justification: body.Justification,
+		TicketID:      body.TicketID,
+		ExpiresAt:     expiresAt.Time,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{"name": grant.Name, "expiresAt": expiresAt})
+}
+
+// Lists the active break-glass grants, for members of the admin group only
+func handleGetBreakGlassGrants(c *gin.Context) {
+	logger.Debug("Entering handleGetBreakGlassGrants")
+
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	adminGroup := viper.GetString(adminGroupKey)
+	if adminGroup == "" || !claims.memberOf(adminGroup) {
+		c.String(http.StatusForbidden, "You are not allowed to list break-glass accesses")
+		return
+	}
+
+	specs, err := activeBreakGlassGrants(time.Now())
+	if err != nil {
+		logger.Errorf("Error listing access grants: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing access grants")
+		return
+	}
+
+	c.JSON(http.StatusOK, specs)
+}
+
+// Records a Warning Event on the Kubeconfig, in its own namespace, when it is an object of the
+// cluster
+func recordBreakGlassEvent(kubeconfig *v1alpha1.Kubeconfig, user string, expiresAt metav1.Time, body breakGlassBody) {
+	ref := kubeconfigEventReference(kubeconfig)
+	if ref == nil {
+		return
+	}
+	kubecfg.recorder.Eventf(ref, corev1.EventTypeWarning, "BreakGlass",
+		"Break-glass access granted to %s until %s (ticket %s): %s",
+		user, expiresAt.Format(time.RFC3339), body.TicketID, body.Justification)
+}
+
+// Returns the specs of the break-glass grants not expired at the given time, expired grants
+// being only deleted by the leader on its next expiry run
+func activeBreakGlassGrants(now time.Time) ([]v1alpha1.AccessGrantSpec, error) {
+	grants, err := kubecfg.grantLister.AccessGrants(viper.GetString(podNamespaceKey)).List(
+		labels.SelectorFromSet(labels.Set{breakGlassGrantLabel: "true"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make([]v1alpha1.AccessGrantSpec, 0, len(grants))
+	for _, grant := range grants {
+		if !grant.Spec.ExpiresAt.After(now) {
+			continue
+		}
+		specs = append(specs, grant.Spec)
+	}
+	return specs, nil
+}
+
+// Sends the break-glass event to the configured webhook, if any
+func notifyBreakGlass(ctx context.Context, event breakGlassEvent) {
+	url := viper.GetString(breakGlassWebhookURLKey)
+	if url == "" {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("Cannot marshal break-glass event: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		logger.Errorf("Cannot create break-glass webhook request: %s", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logger.Errorf("Break-glass webhook failed: %s", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		logger.Errorf("Break-glass webhook returned unexpected status %s", resp.Status)
+	}
+}
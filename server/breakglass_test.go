@@ -0,0 +1,117 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Records the objects of the events along with their messages, which the fake recorder of
+// client-go only identifies by kind
+type objectRecorder struct {
+	record.FakeRecorder
+	objects []*corev1.ObjectReference
+}
+
+func (r *objectRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.objects = append(r.objects, object.(*corev1.ObjectReference))
+	r.FakeRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func TestRecordBreakGlassEvent(t *testing.T) {
+	previousCatalog, previousRecorder := kubecfg.catalog, kubecfg.recorder
+	t.Cleanup(func() { kubecfg.catalog, kubecfg.recorder = previousCatalog, previousRecorder })
+
+	tests := []struct {
+		name       string
+		catalog    Catalog
+		kubeconfig *v1alpha1.Kubeconfig
+		want       *corev1.ObjectReference
+	}{
+		{
+			name:       "pod namespace",
+			catalog:    kubernetesCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: "prod"}},
+			want:       &corev1.ObjectReference{APIVersion: "kubebrowser.io/v1alpha1", Kind: "Kubeconfig", Namespace: "kubebrowser", Name: "prod"},
+		},
+		{
+			name:       "team namespace",
+			catalog:    kubernetesCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "staging"}},
+			want:       &corev1.ObjectReference{APIVersion: "kubebrowser.io/v1alpha1", Kind: "Kubeconfig", Namespace: "team-a", Name: "staging"},
+		},
+		{
+			name:       "cluster kubeconfig",
+			catalog:    kubernetesCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+			want:       &corev1.ObjectReference{APIVersion: "kubebrowser.io/v1alpha1", Kind: "ClusterKubeconfig", Name: "shared"},
+		},
+		{
+			name:       "federated catalog",
+			catalog:    &federatedCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: "prod"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &objectRecorder{FakeRecorder: *record.NewFakeRecorder(1)}
+			kubecfg.catalog, kubecfg.recorder = tt.catalog, recorder
+
+			recordBreakGlassEvent(tt.kubeconfig, "jane@example.com", metav1.NewTime(time.Now().Add(time.Hour)),
+				breakGlassBody{TicketID: "INC-42", Justification: "outage"})
+			close(recorder.Events)
+
+			if tt.want == nil {
+				if len(recorder.objects) != 0 {
+					t.Errorf("recorded events on %+v, want none", recorder.objects)
+				}
+				return
+			}
+			if len(recorder.objects) != 1 || *recorder.objects[0] != *tt.want {
+				t.Fatalf("recorded events on %+v, want %+v", recorder.objects, tt.want)
+			}
+			if event := <-recorder.Events; !strings.HasPrefix(event, "Warning BreakGlass Break-glass access granted to jane@example.com") {
+				t.Errorf("recorded %q, want a BreakGlass Warning", event)
+			}
+		})
+	}
+}
+
+func TestActiveBreakGlassGrants(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	t.Cleanup(func() { viper.Set(podNamespaceKey, "") })
+
+	now := time.Now()
+	breakGlassGrant := func(name string, expiresAt time.Time) *v1alpha1.AccessGrant {
+		grant := testAccessGrant(name, nil, expiresAt)
+		grant.Labels = map[string]string{breakGlassGrantLabel: "true"}
+		grant.Spec.TicketID = name
+		return grant
+	}
+	setTestAccessClient(t, nil, []*v1alpha1.AccessGrant{
+		breakGlassGrant("active", now.Add(time.Hour)),
+		breakGlassGrant("expired", now.Add(-time.Minute)),
+		breakGlassGrant("expiring", now),
+		testAccessGrant("requested", nil, now.Add(time.Hour)),
+	})
+
+	specs, err := activeBreakGlassGrants(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tickets []string
+	for _, spec := range specs {
+		tickets = append(tickets, spec.TicketID)
+	}
+	if want := []string{"active"}; !slices.Equal(tickets, want) {
+		t.Errorf("activeBreakGlassGrants() tickets = %q, want %q", tickets, want)
+	}
+}
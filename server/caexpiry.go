@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// CertificateAuthorityExpiry is the expiry of the certificate authority data of a cluster, as sent
+// to the UI
+type CertificateAuthorityExpiry struct {
+	Cluster string `json:"cluster"`
+	// Subject of the first certificate of the data to expire
+	Subject  string    `json:"subject"`
+	NotAfter time.Time `json:"notAfter"`
+	// Set when the certificate expires within the warning window, or has expired
+	ExpiringSoon bool `json:"expiringSoon,omitempty"`
+}
+
+// Returns the expiry of the certificate authority data of each cluster of a Kubeconfig, skipping
+// the clusters without or with invalid data
+func certificateAuthorityExpiries(kubeconfig *v1alpha1.Kubeconfig) []CertificateAuthorityExpiry {
+	var expiries []CertificateAuthorityExpiry
+	deadline := time.Now().Add(viper.GetDuration(caExpiryWarningWindowKey))
+	for _, cluster := range kubeconfig.Spec.Kubeconfig.Clusters {
+		data := cluster.Cluster.CertificateAuthorityData
+		if data == "" {
+			continue
+		}
+		certs, err := parseCertificateAuthorityData(data)
+		if err != nil {
+			continue
+		}
+		first := certs[0]
+		for _, cert := range certs[1:] {
+			if cert.NotAfter.Before(first.NotAfter) {
+				first = cert
+			}
+		}
+		expiries = append(expiries, CertificateAuthorityExpiry{
+			Cluster:      cluster.Name,
+			Subject:      first.Subject.String(),
+			NotAfter:     first.NotAfter,
+			ExpiringSoon: first.NotAfter.Before(deadline),
+		})
+	}
+	return expiries
+}
+
+func runCertificateAuthorityExpiry(ctx context.Context) {
+	wait.UntilWithContext(ctx, warnCertificateAuthorityExpiry, viper.GetDuration(caExpiryCheckIntervalKey))
+}
+
+// Records a Warning Event on each Kubeconfig of the catalog whose certificate authority data
+// expires within the warning window, or on the object it is imported from. Disabled Kubeconfigs
+// are not served anymore and are skipped.
+func warnCertificateAuthorityExpiry(_ context.Context) {
+	kubeconfigs, err := listKubeconfigs()
+	if err != nil {
+		logger.Errorf("Error listing kubeconfigs to check certificate authorities: %s", err)
+		return
+	}
+
+	now := time.Now()
+	for _, kubeconfig := range kubeconfigs {
+		if kubeconfigDisabled(kubeconfig) {
+			continue
+		}
+		ref := kubeconfigEventReference(kubeconfig)
+		for _, expiry := range certificateAuthorityExpiries(kubeconfig) {
+			if !expiry.ExpiringSoon {
+				continue
+			}
+			notAfter := expiry.NotAfter.UTC().Format(time.RFC3339)
+			if expiry.NotAfter.Before(now) {
+				logger.Warnw("Certificate authority expired", "kubeconfig", kubeconfigID(kubeconfig), "cluster", expiry.Cluster, "notAfter", notAfter)
+				if ref != nil {
+					kubecfg.recorder.Eventf(ref, corev1.EventTypeWarning, "CertificateAuthorityExpired",
+						"Certificate authority %q of cluster %s expired on %s", expiry.Subject, expiry.Cluster, notAfter)
+				}
+				continue
+			}
+			logger.Warnw("Certificate authority expiring soon", "kubeconfig", kubeconfigID(kubeconfig), "cluster", expiry.Cluster, "notAfter", notAfter)
+			if ref != nil {
+				kubecfg.recorder.Eventf(ref, corev1.EventTypeWarning, "CertificateAuthorityExpiring",
+					"Certificate authority %q of cluster %s expires on %s", expiry.Subject, expiry.Cluster, notAfter)
+			}
+		}
+	}
+}
+
+// Returns the reference of the object a Kubeconfig of the catalog comes from: a Kubeconfig, a
+// ClusterKubeconfig, or the Cluster API Cluster or Argo CD Secret it is imported from. Returns nil
+// when the catalog is not read from this cluster.
+func kubeconfigEventReference(kubeconfig *v1alpha1.Kubeconfig) *corev1.ObjectReference {
+	if _, ok := kubecfg.catalog.(kubernetesCatalog); !ok {
+		return nil
+	}
+	ref := &corev1.ObjectReference{
+		Name:            kubeconfig.Name,
+		Namespace:       kubeconfig.Namespace,
+		UID:             kubeconfig.UID,
+		ResourceVersion: kubeconfig.ResourceVersion,
+	}
+	switch {
+	case isCAPIKubeconfig(kubeconfig) || isArgoCDKubeconfig(kubeconfig):
+		ref.APIVersion, ref.Kind = kubeconfig.APIVersion, kubeconfig.Kind
+	case kubeconfig.Namespace == "":
+		ref.APIVersion, ref.Kind = v1alpha1.SchemeGroupVersion.String(), "ClusterKubeconfig"
+	default:
+		ref.APIVersion, ref.Kind = v1alpha1.SchemeGroupVersion.String(), "Kubeconfig"
+	}
+	return ref
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestKubeconfigEventReference(t *testing.T) {
+	previousCatalog := kubecfg.catalog
+	t.Cleanup(func() { kubecfg.catalog = previousCatalog })
+
+	tests := []struct {
+		name       string
+		catalog    Catalog
+		kubeconfig *v1alpha1.Kubeconfig
+		want       *corev1.ObjectReference
+	}{
+		{
+			name:       "kubeconfig",
+			catalog:    kubernetesCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "team-a", UID: "1"}},
+			want:       &corev1.ObjectReference{APIVersion: "kubebrowser.io/v1alpha1", Kind: "Kubeconfig", Name: "prod", Namespace: "team-a", UID: "1"},
+		},
+		{
+			name:       "cluster kubeconfig",
+			catalog:    kubernetesCatalog{},
+			kubeconfig: fromClusterKubeconfig(&v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "shared", UID: "2"}}),
+			want:       &corev1.ObjectReference{APIVersion: "kubebrowser.io/v1alpha1", Kind: "ClusterKubeconfig", Name: "shared", UID: "2"},
+		},
+		{
+			name:    "cluster api cluster",
+			catalog: kubernetesCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{
+				TypeMeta:   metav1.TypeMeta{APIVersion: capiClusterResource.GroupVersion().String(), Kind: "Cluster"},
+				ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "clusters", UID: "3"},
+			},
+			want: &corev1.ObjectReference{APIVersion: capiClusterResource.GroupVersion().String(), Kind: "Cluster", Name: "workload", Namespace: "clusters", UID: "3"},
+		},
+		{
+			name:    "argo cd secret",
+			catalog: kubernetesCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-prod", Namespace: "argocd", UID: "4"},
+			},
+			want: &corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "cluster-prod", Namespace: "argocd", UID: "4"},
+		},
+		{
+			name:       "other catalog",
+			catalog:    &federatedCatalog{},
+			kubeconfig: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "kubebrowser", UID: "5"}},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubecfg.catalog = tt.catalog
+			got := kubeconfigEventReference(tt.kubeconfig)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("kubeconfigEventReference() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarnCertificateAuthorityExpiry(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	viper.Set(watchNamespacesKey, "team-a")
+	previousCatalog, previousRecorder := kubecfg.catalog, kubecfg.recorder
+	t.Cleanup(func() {
+		viper.Set(podNamespaceKey, "")
+		viper.Set(watchNamespacesKey, "")
+		kubecfg.catalog, kubecfg.recorder = previousCatalog, previousRecorder
+	})
+
+	kubeconfig := func(name, namespace string, notAfter time.Time) *v1alpha1.Kubeconfig {
+		spec := testKubeconfigSpec(name)
+		spec.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData = testCertificateAuthorityData(t, name+"-ca", notAfter)
+		return &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	}
+	shared := kubeconfig("shared", "", time.Now().Add(-time.Hour))
+	retired := kubeconfig("retired", "kubebrowser", time.Now().Add(-time.Hour))
+	retired.Spec.Lifecycle = &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDisabled}
+	setTestListers(t,
+		[]*v1alpha1.Kubeconfig{
+			kubeconfig("prod", "kubebrowser", time.Now().Add(365*24*time.Hour)),
+			kubeconfig("staging", "team-a", time.Now().Add(24*time.Hour)),
+			kubeconfig("dev", "team-b", time.Now().Add(24*time.Hour)),
+			retired,
+		},
+		[]*v1alpha1.ClusterKubeconfig{{ObjectMeta: shared.ObjectMeta, Spec: shared.Spec}},
+	)
+	recorder := record.NewFakeRecorder(10)
+	recorder.IncludeObject = true
+	kubecfg.catalog, kubecfg.recorder = kubernetesCatalog{}, recorder
+
+	warnCertificateAuthorityExpiry(t.Context())
+	close(recorder.Events)
+
+	var events []string
+	for event := range recorder.Events {
+		reason, object, _ := strings.Cut(event, " involvedObject")
+		reason = strings.Join(strings.Fields(reason)[:2], " ")
+		kind := strings.TrimPrefix(strings.Split(object, ",")[0], "{kind=")
+		events = append(events, reason+" "+kind)
+	}
+	slices.Sort(events)
+	want := []string{
+		"Warning CertificateAuthorityExpired ClusterKubeconfig",
+		"Warning CertificateAuthorityExpiring Kubeconfig",
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+}
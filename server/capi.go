@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// Prefix of the IDs of Kubeconfigs imported from Cluster API, followed by namespace/name
+	capiIDPrefix = "capi:"
+
+	// Label Cluster API puts on the Secrets of a Cluster
+	capiClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+	// Cluster API writes the kubeconfig of a Cluster in the "value" key of this Secret
+	capiKubeconfigSecretSuffix = "-kubeconfig"
+	capiKubeconfigSecretKey    = "value"
+)
+
+var capiClusterResource = schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "clusters"}
+
+// Watches Cluster API Clusters and their kubeconfig Secrets in the namespace, all namespaces if
+// empty. Returns the functions telling whether the informers are synced.
+func (k *Kubecfg) initCAPI(ctx context.Context, cfg *rest.Config, namespace string) ([]cache.InformerSynced, error) {
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterInformerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+		dynamicClient,
+		viper.GetDuration(informerResyncKey),
+		namespace,
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = viper.GetString(capiClusterSelectorKey)
+		},
+	)
+	clusterInformer := clusterInformerFactory.ForResource(capiClusterResource)
+	k.capiLister = clusterInformer.Lister()
+
+	if err := publishCatalogChanges(clusterInformer.Informer(), nil); err != nil {
+		return nil, err
+	}
+	clusterInformerFactory.Start(ctx.Done())
+	synced := []cache.InformerSynced{clusterInformer.Informer().HasSynced}
+
+	// Kubeconfig Secrets are read from the namespaces Secrets can be read in
+	secretListers := namespacedSecretLister{}
+	for _, secretNamespace := range sourceNamespaces() {
+		secretInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(
+			k.kubeClient,
+			viper.GetDuration(informerResyncKey),
+			kubeinformers.WithNamespace(secretNamespace),
+			kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.LabelSelector = capiClusterNameLabel
+			}),
+		)
+		secretInformer := secretInformerFactory.Core().V1().Secrets()
+		if err := secretInformer.Informer().SetTransform(stripCAPISecret); err != nil {
+			return nil, err
+		}
+		if err := publishCatalogChanges(secretInformer.Informer(), nil); err != nil {
+			return nil, err
+		}
+		secretListers[secretNamespace] = secretInformer.Lister()
+		secretInformerFactory.Start(ctx.Done())
+		synced = append(synced, secretInformer.Informer().HasSynced)
+	}
+	k.capiSecretLister = secretListers
+
+	return synced, nil
+}
+
+// Keeps only the kubeconfig of the Cluster API Secrets in the cache, the other Secrets of a Cluster
+// hold the private keys of its certificate authorities, service accounts and etcd
+func stripCAPISecret(obj any) (any, error) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return obj, nil
+	}
+
+	stripped := &corev1.Secret{ObjectMeta: strippedObjectMeta(secret.ObjectMeta), Type: secret.Type}
+	if value, ok := secret.Data[capiKubeconfigSecretKey]; ok && strings.HasSuffix(secret.Name, capiKubeconfigSecretSuffix) {
+		stripped.Data = map[string][]byte{capiKubeconfigSecretKey: value}
+	}
+	return stripped, nil
+}
+
+// Lists the Kubeconfigs of the Cluster API Clusters of the watched namespaces. Clusters without
+// kubeconfig Secret yet are skipped.
+func listCAPIKubeconfigs() ([]*v1alpha1.Kubeconfig, error) {
+	if kubecfg.capiLister == nil {
+		return nil, nil
+	}
+
+	clusters, err := kubecfg.capiLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigs := make([]*v1alpha1.Kubeconfig, 0, len(clusters))
+	for _, obj := range clusters {
+		cluster, ok := obj.(*unstructured.Unstructured)
+		if !ok || !watchedNamespace(cluster.GetNamespace()) {
+			continue
+		}
+		kubeconfig, err := capiKubeconfig(cluster)
+		if err != nil {
+			logger.Debugw("Skipping Cluster API cluster", "namespace", cluster.GetNamespace(), "name", cluster.GetName(), "error", err)
+			continue
+		}
+		kubeconfigs = append(kubeconfigs, kubeconfig)
+	}
+	return kubeconfigs, nil
+}
+
+// Returns the Kubeconfig of a Cluster API Cluster from the namespace/name part of its ID
+func getCAPIKubeconfig(key string) (*v1alpha1.Kubeconfig, error) {
+	namespace, name, ok := strings.Cut(key, "/")
+	if kubecfg.capiLister == nil || !ok || !watchedNamespace(namespace) {
+		return nil, apierrors.NewNotFound(capiClusterResource.GroupResource(), key)
+	}
+
+	obj, err := kubecfg.capiLister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	cluster, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object for cluster API cluster %s", key)
+	}
+	return capiKubeconfig(cluster)
+}
+
+// Builds a Kubeconfig from a Cluster API Cluster and its kubeconfig Secret, without the
+// credentials of the Secret
+func capiKubeconfig(cluster *unstructured.Unstructured) (*v1alpha1.Kubeconfig, error) {
+	secret, err := kubecfg.capiSecretLister.Secrets(cluster.GetNamespace()).Get(cluster.GetName() + capiKubeconfigSecretSuffix)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[capiKubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s", capiKubeconfigSecretKey, secret.Name)
+	}
+
+	data, err := loadWithoutCredentials(value)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := cluster.GetAnnotations()
+	return &v1alpha1.Kubeconfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: capiClusterResource.GroupVersion().String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.GetName(),
+			Namespace:   cluster.GetNamespace(),
+			UID:         cluster.GetUID(),
+			Labels:      cluster.GetLabels(),
+			Annotations: annotations,
+		},
+		Spec: annotatedKubeconfigSpec(annotations, cluster.GetName(), data),
+	}, nil
+}
+
+// Returns whether the Kubeconfig is imported from a Cluster API Cluster
+func isCAPIKubeconfig(kubeconfig *v1alpha1.Kubeconfig) bool {
+	return kubeconfig.APIVersion == capiClusterResource.GroupVersion().String() && kubeconfig.Kind == "Cluster"
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"maps"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestStripCAPISecret(t *testing.T) {
+	secret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:     "clusters",
+				Name:          name,
+				Labels:        map[string]string{capiClusterNameLabel: "prod"},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "capi"}},
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: `{"data":{"value":"a3ViZWNvbmZpZw=="}}`,
+					displayNameAnnotation:              "Production",
+				},
+			},
+			Type: "cluster.x-k8s.io/secret",
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   map[string][]byte
+	}{
+		{
+			name:   "kubeconfig",
+			secret: secret("prod-kubeconfig", map[string][]byte{"value": []byte("kubeconfig"), "extra": []byte("x")}),
+			want:   map[string][]byte{"value": []byte("kubeconfig")},
+		},
+		{
+			name:   "certificate authority",
+			secret: secret("prod-ca", map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte("key")}),
+			want:   nil,
+		},
+		{
+			name:   "etcd with a value key",
+			secret: secret("prod-etcd", map[string][]byte{"value": []byte("key")}),
+			want:   nil,
+		},
+		{
+			name:   "kubeconfig without value",
+			secret: secret("prod-kubeconfig", map[string][]byte{"other": []byte("x")}),
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := stripCAPISecret(tt.secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stripped := obj.(*corev1.Secret)
+			if !maps.EqualFunc(stripped.Data, tt.want, func(a, b []byte) bool { return string(a) == string(b) }) {
+				t.Errorf("Data = %v, want %v", stripped.Data, tt.want)
+			}
+			if stripped.Name != tt.secret.Name || stripped.Labels[capiClusterNameLabel] != "prod" {
+				t.Errorf("metadata not kept: %+v", stripped.ObjectMeta)
+			}
+			if _, ok := stripped.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+				t.Errorf("Annotations = %v, want no last applied configuration", stripped.Annotations)
+			}
+			if stripped.Annotations[displayNameAnnotation] != "Production" {
+				t.Errorf("Annotations = %v, want the display name kept", stripped.Annotations)
+			}
+			if stripped.ManagedFields != nil {
+				t.Errorf("ManagedFields = %v, want nil", stripped.ManagedFields)
+			}
+			if len(tt.secret.Data) == 0 || tt.secret.ManagedFields == nil || tt.secret.Annotations[corev1.LastAppliedConfigAnnotation] == "" {
+				t.Errorf("the informed object was modified")
+			}
+		})
+	}
+
+	tombstone := cache.DeletedFinalStateUnknown{Key: "clusters/prod-ca"}
+	if obj, err := stripCAPISecret(tombstone); err != nil || obj != tombstone {
+		t.Errorf("stripCAPISecret(tombstone) = %v, %v", obj, err)
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// Number of catalog changes kept to resume event streams
+	catalogChangeHistory = 256
+	// Interval between heartbeats when none, or a non positive one, is configured
+	defaultEventsHeartbeatInterval = 30 * time.Second
+)
+
+var (
+	errTooManyConnections     = errors.New("too many event stream connections")
+	errTooManyUserConnections = errors.New("too many event stream connections for this user")
+)
+
+// Change of the catalog, with the IDs of the changed Kubeconfigs, nil when any Kubeconfig may
+// have changed
+type catalogChange struct {
+	version uint64
+	ids     []string
+}
+
+// Versioned log of the catalog changes, notifying the event streams of the users
+type catalogChangeLog struct {
+	// Identifies the process in event IDs, as versions are not shared between replicas
+	epoch   string
+	mu      sync.Mutex
+	version uint64
+	changes []catalogChange
+	// Notified of every change, without blocking
+	subscribers     map[chan struct{}]bool
+	userConnections map[string]int
+}
+
+var catalogChanges = &catalogChangeLog{
+	epoch:           strconv.FormatInt(time.Now().UnixNano(), 36),
+	subscribers:     map[chan struct{}]bool{},
+	userConnections: map[string]int{},
+}
+
+// Records a change of the Kubeconfigs with these IDs, or of any Kubeconfig without IDs
+func (l *catalogChangeLog) publish(ids ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.version++
+	l.changes = append(l.changes, catalogChange{version: l.version, ids: ids})
+	if len(l.changes) > catalogChangeHistory {
+		l.changes = l.changes[len(l.changes)-catalogChangeHistory:]
+	}
+	for subscriber := range l.subscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (l *catalogChangeLog) currentVersion() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.version
+}
+
+// Returns the IDs of the Kubeconfigs changed after the version, or false if they are not known
+func (l *catalogChangeLog) changedSince(version uint64) ([]string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if version > l.version {
+		return nil, false
+	}
+	if version == l.version {
+		return nil, true
+	}
+	if len(l.changes) == 0 || l.changes[0].version > version+1 {
+		return nil, false
+	}
+
+	var ids []string
+	for _, change := range l.changes {
+		if change.version <= version {
+			continue
+		}
+		if change.ids == nil {
+			return nil, false
+		}
+		for _, id := range change.ids {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, true
+}
+
+// Returns the IDs of the Kubeconfigs changed after the event ID, or false if they are not known
+func (l *catalogChangeLog) resume(lastEventID string) ([]string, bool) {
+	epoch, version, ok := strings.Cut(lastEventID, "-")
+	if !ok || epoch != l.epoch {
+		return nil, false
+	}
+	lastVersion, err := strconv.ParseUint(version, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return l.changedSince(lastVersion)
+}
+
+// Registers an event stream of the user, within the connection limits
+func (l *catalogChangeLog) subscribe(user string) (chan struct{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.subscribers) >= viper.GetInt(eventsMaxConnectionsKey) {
+		return nil, errTooManyConnections
+	}
+	if l.userConnections[user] >= viper.GetInt(eventsMaxUserConnectionsKey) {
+		return nil, errTooManyUserConnections
+	}
+	subscriber := make(chan struct{}, 1)
+	l.subscribers[subscriber] = true
+	l.userConnections[user]++
+	return subscriber, nil
+}
+
+func (l *catalogChangeLog) unsubscribe(user string, subscriber chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.subscribers, subscriber)
+	if l.userConnections[user]--; l.userConnections[user] <= 0 {
+		delete(l.userConnections, user)
+	}
+}
+
+// Publishes the changes of the objects of an informer, as the Kubeconfig IDs returned for them,
+// or as a change of any Kubeconfig when the function is nil. Resyncs are ignored.
+func publishCatalogChanges(informer cache.SharedIndexInformer, kubeconfigIDs func(obj any) []string) error {
+	publish := func(obj any) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if kubeconfigIDs == nil {
+			catalogChanges.publish()
+		} else if ids := kubeconfigIDs(obj); len(ids) > 0 {
+			catalogChanges.publish(ids...)
+		}
+	}
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: publish,
+		UpdateFunc: func(old, obj any) {
+			oldObject, oldOK := old.(metav1.Object)
+			object, ok := obj.(metav1.Object)
+			if oldOK && ok && oldObject.GetResourceVersion() == object.GetResourceVersion() {
+				return
+			}
+			publish(obj)
+		},
+		DeleteFunc: publish,
+	})
+	return err
+}
+
+// Returns the ID of a Kubeconfig object of an informer
+func kubeconfigObjectID(obj any) []string {
+	kubeconfig, ok := obj.(*v1alpha1.Kubeconfig)
+	if !ok {
+		return nil
+	}
+	return []string{kubeconfigID(kubeconfig)}
+}
+
+// Streams the changes of the Kubeconfigs of the user as Server-Sent Events:
+//   - snapshot, with all the Kubeconfigs, when the stream starts or cannot be resumed
+//   - add and update, with a Kubeconfig
+//   - delete, with the ID of a Kubeconfig
+//
+// A stream resumed with Last-Event-ID receives an update for each Kubeconfig changed since that
+// event. When one of them is not visible to the user, the stream receives a snapshot instead, as a
+// delete would reveal the ID of a Kubeconfig the user may never have seen.
+func handleKubeconfigEvents(c *gin.Context) {
+	claims, err := userClaims(c)
+	if err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	subscriber, err := catalogChanges.subscribe(claims.Email)
+	if errors.Is(err, errTooManyUserConnections) {
+		c.String(http.StatusTooManyRequests, "Too many event streams")
+		return
+	} else if err != nil {
+		c.String(http.StatusServiceUnavailable, "Too many event streams")
+		return
+	}
+	defer catalogChanges.unsubscribe(claims.Email, subscriber)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // Disable the buffering of nginx
+	c.Status(http.StatusOK)
+	fmt.Fprint(c.Writer, "retry: 5000\n\n")
+
+	version := catalogChanges.currentVersion()
+	views, err := userKubeconfigViews(c)
+	if err != nil {
+		logger.Errorf("Error preparing kubeconfigs: %s", err)
+		return
+	}
+	sent, err := kubeconfigViewsByID(views)
+	if err != nil {
+		logger.Errorf("Error encoding kubeconfigs: %s", err)
+		return
+	}
+
+	changed, resumed := catalogChanges.resume(c.GetHeader("Last-Event-ID"))
+	updates, resumed := resumedUpdates(changed, resumed, sent)
+	if !resumed {
+		if err := writeEvent(c, version, "snapshot", views); err != nil {
+			return
+		}
+	}
+	for _, data := range updates {
+		if err := writeEvent(c, version, "update", json.RawMessage(data)); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval())
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-subscriber:
+			version := catalogChanges.currentVersion()
+			views, err := userKubeconfigViews(c)
+			if err != nil {
+				logger.Errorf("Error preparing kubeconfigs: %s", err)
+				return
+			}
+			current, err := kubeconfigViewsByID(views)
+			if err != nil {
+				logger.Errorf("Error encoding kubeconfigs: %s", err)
+				return
+			}
+			if err := writeKubeconfigChanges(c, version, sent, current); err != nil {
+				return
+			}
+			sent = current
+		}
+	}
+}
+
+// Returns the Kubeconfigs to send as updates to a resumed stream, or false if the stream must
+// receive a snapshot because it was not resumed or a changed Kubeconfig is not visible to the user
+func resumedUpdates(changed []string, resumed bool, visible map[string][]byte) ([][]byte, bool) {
+	if !resumed {
+		return nil, false
+	}
+	updates := make([][]byte, 0, len(changed))
+	for _, id := range changed {
+		data, ok := visible[id]
+		if !ok {
+			return nil, false
+		}
+		updates = append(updates, data)
+	}
+	return updates, true
+}
+
+// Writes the add, update and delete events turning the sent Kubeconfigs into the current ones
+func writeKubeconfigChanges(c *gin.Context, version uint64, sent, current map[string][]byte) error {
+	for id, data := range current {
+		previous, ok := sent[id]
+		var err error
+		if !ok {
+			err = writeEvent(c, version, "add", json.RawMessage(data))
+		} else if !bytes.Equal(previous, data) {
+			err = writeEvent(c, version, "update", json.RawMessage(data))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for id := range sent {
+		if _, ok := current[id]; !ok {
+			if err := writeEvent(c, version, "delete", gin.H{"id": id}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the JSON encoding of the views, by ID
+func kubeconfigViewsByID(views []KubeconfigView) (map[string][]byte, error) {
+	byID := make(map[string][]byte, len(views))
+	for _, view := range views {
+		data, err := json.Marshal(view)
+		if err != nil {
+			return nil, err
+		}
+		byID[view.ID] = data
+	}
+	return byID, nil
+}
+
+func writeEvent(c *gin.Context, version uint64, event string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(c.Writer, "id: %s-%d\nevent: %s\ndata: %s\n\n", catalogChanges.epoch, version, event, encoded); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// Returns the interval between heartbeats, falling back to the default when the configured one
+// is not positive, which would make the ticker panic
+func eventsHeartbeatInterval() time.Duration {
+	if interval := viper.GetDuration(eventsHeartbeatIntervalKey); interval > 0 {
+		return interval
+	}
+	return defaultEventsHeartbeatInterval
+}
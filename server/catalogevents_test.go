@@ -0,0 +1,123 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestCatalogChangesResume(t *testing.T) {
+	log := &catalogChangeLog{epoch: "epoch"}
+	log.publish("team-a/prod")  // version 1
+	log.publish("staging")      // version 2
+	log.publish("team-a/prod")  // version 3
+	log.publish()               // version 4, any Kubeconfig
+	log.publish("dev", "tools") // version 5
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		wantIDs     []string
+		wantResumed bool
+	}{
+		{name: "no event ID", lastEventID: ""},
+		{name: "other epoch", lastEventID: "other-1"},
+		{name: "invalid version", lastEventID: "epoch-x"},
+		{name: "future version", lastEventID: "epoch-6"},
+		{name: "before an unknown change", lastEventID: "epoch-2"},
+		{name: "current version", lastEventID: "epoch-5", wantResumed: true},
+		{name: "after an unknown change", lastEventID: "epoch-4", wantIDs: []string{"dev", "tools"}, wantResumed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, resumed := log.resume(tt.lastEventID)
+			if resumed != tt.wantResumed || !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("resume(%q) = %q, %v, want %q, %v", tt.lastEventID, ids, resumed, tt.wantIDs, tt.wantResumed)
+			}
+		})
+	}
+}
+
+func TestCatalogChangesResumeDeduplicates(t *testing.T) {
+	log := &catalogChangeLog{epoch: "epoch"}
+	log.publish("team-a/prod")
+	log.publish("staging")
+	log.publish("team-a/prod")
+
+	ids, resumed := log.resume("epoch-0")
+	if want := []string{"team-a/prod", "staging"}; !resumed || !slices.Equal(ids, want) {
+		t.Errorf("resume() = %q, %v, want %q, true", ids, resumed, want)
+	}
+}
+
+func TestCatalogChangesResumeExpiredHistory(t *testing.T) {
+	log := &catalogChangeLog{epoch: "epoch"}
+	for i := 0; i < catalogChangeHistory+1; i++ {
+		log.publish("prod")
+	}
+
+	if _, resumed := log.resume("epoch-0"); resumed {
+		t.Error("resume() resumed past the change history")
+	}
+	if _, resumed := log.resume("epoch-1"); !resumed {
+		t.Error("resume() did not resume within the change history")
+	}
+}
+
+func TestResumedUpdates(t *testing.T) {
+	visible := map[string][]byte{
+		"prod":    []byte(`{"id":"prod"}`),
+		"staging": []byte(`{"id":"staging"}`),
+	}
+
+	tests := []struct {
+		name        string
+		changed     []string
+		resumed     bool
+		wantUpdates []string
+		wantResumed bool
+	}{
+		{name: "not resumed", changed: nil, resumed: false},
+		{name: "no changes", changed: nil, resumed: true, wantResumed: true},
+		{name: "visible changes", changed: []string{"staging", "prod"}, resumed: true, wantUpdates: []string{`{"id":"staging"}`, `{"id":"prod"}`}, wantResumed: true},
+		{name: "change not visible", changed: []string{"prod", "team-b/secret"}, resumed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, resumed := resumedUpdates(tt.changed, tt.resumed, visible)
+			var got []string
+			for _, data := range updates {
+				got = append(got, string(data))
+			}
+			if resumed != tt.wantResumed || !slices.Equal(got, tt.wantUpdates) {
+				t.Errorf("resumedUpdates() = %q, %v, want %q, %v", got, resumed, tt.wantUpdates, tt.wantResumed)
+			}
+		})
+	}
+}
+
+func TestEventsHeartbeatInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval string
+		want     time.Duration
+	}{
+		{name: "configured", interval: "10s", want: 10 * time.Second},
+		{name: "zero", interval: "0", want: defaultEventsHeartbeatInterval},
+		{name: "negative", interval: "-5s", want: defaultEventsHeartbeatInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(eventsHeartbeatIntervalKey, tt.interval)
+			t.Cleanup(func() { viper.Set(eventsHeartbeatIntervalKey, defaultEventsHeartbeatInterval) })
+
+			if got := eventsHeartbeatInterval(); got != tt.want {
+				t.Errorf("eventsHeartbeatInterval() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// Labels classifying Kubeconfigs in the catalog
+	environmentLabel = "kubebrowser.io/environment"
+	providerLabel    = "kubebrowser.io/provider"
+	ownerLabel       = "kubebrowser.io/owner"
+
+	// Headers of a page of the catalog
+	totalCountHeader = "X-Total-Count"
+	continueHeader   = "X-Continue"
+
+	maxPageSize = 500
+)
+
+// Fields of a Kubeconfig the catalog can be sorted by
+var kubeconfigFields = map[string]func(*v1alpha1.Kubeconfig) string{
+	"name":        func(k *v1alpha1.Kubeconfig) string { return k.Spec.Name },
+	"id":          kubeconfigID,
+	"namespace":   func(k *v1alpha1.Kubeconfig) string { return k.Namespace },
+	"source":      kubeconfigSource,
+	"environment": kubeconfigEnvironment,
+	"region":      func(k *v1alpha1.Kubeconfig) string { return k.Spec.Region },
+	"provider":    kubeconfigProvider,
+	"owner":       kubeconfigOwner,
+}
+
+// Fields of a Kubeconfig the catalog can be filtered on, with a query parameter of the same name.
+// A Kubeconfig matches when any of its values is accepted.
+var kubeconfigFilterFields = map[string]func(*v1alpha1.Kubeconfig) []string{
+	"environment": func(k *v1alpha1.Kubeconfig) []string { return []string{kubeconfigEnvironment(k)} },
+	"region":      func(k *v1alpha1.Kubeconfig) []string { return []string{k.Spec.Region} },
+	"provider":    func(k *v1alpha1.Kubeconfig) []string { return []string{kubeconfigProvider(k)} },
+	"owner":       func(k *v1alpha1.Kubeconfig) []string { return []string{kubeconfigOwner(k)} },
+	"namespace":   func(k *v1alpha1.Kubeconfig) []string { return []string{k.Namespace} },
+	"source":      func(k *v1alpha1.Kubeconfig) []string { return []string{kubeconfigSource(k)} },
+	"tag":         func(k *v1alpha1.Kubeconfig) []string { return k.Spec.Tags },
+	"state":       func(k *v1alpha1.Kubeconfig) []string { return []string{string(kubeconfigState(k))} },
+}
+
+// Returns the environment of a Kubeconfig, such as prod or dev, from its spec or its label
+func kubeconfigEnvironment(kubeconfig *v1alpha1.Kubeconfig) string {
+	if kubeconfig.Spec.Environment != "" {
+		return kubeconfig.Spec.Environment
+	}
+	return kubeconfig.Labels[environmentLabel]
+}
+
+// Returns the provider hosting the cluster of a Kubeconfig, such as aws or on-premise, from its
+// spec or its label
+func kubeconfigProvider(kubeconfig *v1alpha1.Kubeconfig) string {
+	if kubeconfig.Spec.Provider != "" {
+		return kubeconfig.Spec.Provider
+	}
+	return kubeconfig.Labels[providerLabel]
+}
+
+// Returns the owner of a Kubeconfig from its spec or its label, defaulting to the team owning its
+// namespace
+func kubeconfigOwner(kubeconfig *v1alpha1.Kubeconfig) string {
+	if kubeconfig.Spec.Owner != "" {
+		return kubeconfig.Spec.Owner
+	}
+	if owner := kubeconfig.Labels[ownerLabel]; owner != "" {
+		return owner
+	}
+	return kubeconfigTeam(kubeconfig)
+}
+
+// Search, filters, sort and page of the catalog requested by a user
+type kubeconfigQuery struct {
+	// Case-insensitive text searched in the display name, the description, the ID and the tags
+	search   string
+	selector labels.Selector
+	// Accepted values of filtered fields
+	fields     map[string][]string
+	sortField  string
+	descending bool
+	limit      int
+	// Sort value and ID of the last Kubeconfig of the previous page
+	after []string
+}
+
+// Parses the q, labelSelector, field filter, sort, limit and continue query parameters. Filters
+// accept comma-separated values, sort is a field prefixed with - for a descending order.
+func parseKubeconfigQuery(c *gin.Context) (*kubeconfigQuery, error) {
+	query := &kubeconfigQuery{
+		search:    strings.ToLower(strings.TrimSpace(c.Query("q"))),
+		selector:  labels.Everything(),
+		fields:    map[string][]string{},
+		sortField: "name",
+	}
+
+	if selector := c.Query("labelSelector"); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("labelSelector: %w", err)
+		}
+		query.selector = parsed
+	}
+
+	for field := range kubeconfigFilterFields {
+		if values := splitList(c.Query(field)); len(values) > 0 {
+			query.fields[field] = values
+		}
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		query.sortField, query.descending = strings.CutPrefix(sort, "-")
+		if _, ok := kubeconfigFields[query.sortField]; !ok {
+			return nil, fmt.Errorf("sort: unknown field %q", query.sortField)
+		}
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		var err error
+		query.limit, err = strconv.Atoi(limit)
+		if err != nil || query.limit < 1 || query.limit > maxPageSize {
+			return nil, fmt.Errorf("limit: must be between 1 and %d", maxPageSize)
+		}
+	}
+
+	if token := c.Query("continue"); token != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err == nil {
+			err = json.Unmarshal(raw, &query.after)
+		}
+		if err != nil || len(query.after) != 2 {
+			return nil, errors.New("continue: invalid token")
+		}
+	}
+	return query, nil
+}
+
+// Returns the page of the Kubeconfigs matching the query, the number of matching Kubeconfigs and
+// the token of the next page, empty on the last page. Pages start after the last Kubeconfig of
+// the previous page, so they stay consistent when the catalog changes.
+func (q *kubeconfigQuery) apply(kubeconfigs []*v1alpha1.Kubeconfig) ([]*v1alpha1.Kubeconfig, int, string) {
+	matching := make([]*v1alpha1.Kubeconfig, 0, len(kubeconfigs))
+	for _, kubeconfig := range kubeconfigs {
+		if q.matches(kubeconfig) {
+			matching = append(matching, kubeconfig)
+		}
+	}
+
+	sortValue := kubeconfigFields[q.sortField]
+	compare := func(value, id string, other *v1alpha1.Kubeconfig) int {
+		order := cmp.Compare(strings.ToLower(value), strings.ToLower(sortValue(other)))
+		if q.descending {
+			order = -order
+		}
+		if order != 0 {
+			return order
+		}
+		return cmp.Compare(id, kubeconfigID(other))
+	}
+	slices.SortFunc(matching, func(a, b *v1alpha1.Kubeconfig) int {
+		return compare(sortValue(a), kubeconfigID(a), b)
+	})
+
+	page := matching
+	if q.after != nil {
+		start, _ := slices.BinarySearchFunc(matching, q.after, func(k *v1alpha1.Kubeconfig, after []string) int {
+			return -compare(after[0], after[1], k)
+		})
+		// Skip the last Kubeconfig of the previous page if it still exists
+		if start < len(matching) && kubeconfigID(matching[start]) == q.after[1] {
+			start++
+		}
+		page = matching[start:]
+	}
+	if q.limit == 0 || len(page) <= q.limit {
+		return page, len(matching), ""
+	}
+
+	page = page[:q.limit]
+	last := page[len(page)-1]
+	token, _ := json.Marshal([]string{sortValue(last), kubeconfigID(last)})
+	return page, len(matching), base64.RawURLEncoding.EncodeToString(token)
+}
+
+func (q *kubeconfigQuery) matches(kubeconfig *v1alpha1.Kubeconfig) bool {
+	if q.search != "" && !slices.ContainsFunc(kubeconfigSearchedTexts(kubeconfig), func(text string) bool {
+		return strings.Contains(strings.ToLower(text), q.search)
+	}) {
+		return false
+	}
+	if !q.selector.Matches(labels.Set(kubeconfig.Labels)) {
+		return false
+	}
+	for field, values := range q.fields {
+		if !slices.ContainsFunc(kubeconfigFilterFields[field](kubeconfig), func(value string) bool {
+			return slices.Contains(values, value)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the texts of a Kubeconfig searched by the q query parameter
+func kubeconfigSearchedTexts(kubeconfig *v1alpha1.Kubeconfig) []string {
+	texts := []string{kubeconfig.Spec.Name, kubeconfig.Spec.Description, kubeconfigID(kubeconfig)}
+	return append(texts, kubeconfig.Spec.Tags...)
+}
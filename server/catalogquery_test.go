@@ -0,0 +1,233 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestKubeconfigQueryApply(t *testing.T) {
+	kubeconfig := func(name, displayName, environment string, tags ...string) *v1alpha1.Kubeconfig {
+		spec := testKubeconfigSpec(displayName)
+		spec.Environment = environment
+		spec.Tags = tags
+		return &v1alpha1.Kubeconfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tier": environment}},
+			Spec:       spec,
+		}
+	}
+	kubeconfigs := []*v1alpha1.Kubeconfig{
+		kubeconfig("dev", "Development", "dev", "gpu"),
+		kubeconfig("prod-eu", "Production EU", "prod"),
+		kubeconfig("prod-us", "Production US", "prod", "gpu"),
+		kubeconfig("staging", "Staging", "staging"),
+	}
+	query := func(mutate func(q *kubeconfigQuery)) *kubeconfigQuery {
+		q := &kubeconfigQuery{selector: labels.Everything(), fields: map[string][]string{}, sortField: "name"}
+		if mutate != nil {
+			mutate(q)
+		}
+		return q
+	}
+
+	tests := []struct {
+		name      string
+		query     *kubeconfigQuery
+		wantIDs   []string
+		wantTotal int
+		wantNext  bool
+	}{
+		{
+			name:      "everything sorted by name",
+			query:     query(nil),
+			wantIDs:   []string{"dev", "prod-eu", "prod-us", "staging"},
+			wantTotal: 4,
+		},
+		{
+			name:      "descending",
+			query:     query(func(q *kubeconfigQuery) { q.descending = true }),
+			wantIDs:   []string{"staging", "prod-us", "prod-eu", "dev"},
+			wantTotal: 4,
+		},
+		{
+			name:      "search is case-insensitive",
+			query:     query(func(q *kubeconfigQuery) { q.search = "production" }),
+			wantIDs:   []string{"prod-eu", "prod-us"},
+			wantTotal: 2,
+		},
+		{
+			name:      "search in tags",
+			query:     query(func(q *kubeconfigQuery) { q.search = "gpu" }),
+			wantIDs:   []string{"dev", "prod-us"},
+			wantTotal: 2,
+		},
+		{
+			name:      "field filter accepts any value",
+			query:     query(func(q *kubeconfigQuery) { q.fields["environment"] = []string{"dev", "staging"} }),
+			wantIDs:   []string{"dev", "staging"},
+			wantTotal: 2,
+		},
+		{
+			name: "label selector",
+			query: query(func(q *kubeconfigQuery) {
+				q.selector = labels.SelectorFromSet(labels.Set{"tier": "prod"})
+			}),
+			wantIDs:   []string{"prod-eu", "prod-us"},
+			wantTotal: 2,
+		},
+		{
+			name:      "first page",
+			query:     query(func(q *kubeconfigQuery) { q.limit = 2 }),
+			wantIDs:   []string{"dev", "prod-eu"},
+			wantTotal: 4,
+			wantNext:  true,
+		},
+		{
+			name: "next page",
+			query: query(func(q *kubeconfigQuery) {
+				q.limit = 2
+				q.after = []string{"Production EU", "prod-eu"}
+			}),
+			wantIDs:   []string{"prod-us", "staging"},
+			wantTotal: 4,
+		},
+		{
+			name: "next page after a deleted Kubeconfig",
+			query: query(func(q *kubeconfigQuery) {
+				q.limit = 2
+				q.after = []string{"Production", "prod"}
+			}),
+			wantIDs:   []string{"prod-eu", "prod-us"},
+			wantTotal: 4,
+			wantNext:  true,
+		},
+		{
+			name:      "no match",
+			query:     query(func(q *kubeconfigQuery) { q.search = "unknown" }),
+			wantIDs:   []string{},
+			wantTotal: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total, next := tt.query.apply(slices.Clone(kubeconfigs))
+			ids := make([]string, 0, len(page))
+			for _, kubeconfig := range page {
+				ids = append(ids, kubeconfigID(kubeconfig))
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("apply() page = %q, want %q", ids, tt.wantIDs)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("apply() total = %d, want %d", total, tt.wantTotal)
+			}
+			if (next != "") != tt.wantNext {
+				t.Errorf("apply() next = %q, want a token: %v", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestServedKubeconfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(spec *v1alpha1.KubeconfigSpec)
+		want   bool
+	}{
+		{
+			name: "valid",
+			want: true,
+		},
+		{
+			name: "disabled",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Lifecycle = &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDisabled}
+			},
+		},
+		{
+			name: "deprecated",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Lifecycle = &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDeprecated}
+			},
+			want: true,
+		},
+		{
+			name:   "no context",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) { spec.Kubeconfig.Contexts = nil },
+		},
+		{
+			name:   "rejected by client-go",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) { spec.Kubeconfig.Contexts[0].Context.Cluster = "unknown" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testKubeconfigSpec("Production")
+			if tt.mutate != nil {
+				tt.mutate(&spec)
+			}
+			kubeconfig := &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "prod"}, Spec: spec}
+			if got := servedKubeconfig(kubeconfig); got != tt.want {
+				t.Errorf("servedKubeconfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeconfigMetadataFields(t *testing.T) {
+	setTestNamespaces(t, "", &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{teamLabel: "alpha"}}})
+
+	tests := []struct {
+		name            string
+		namespace       string
+		spec            v1alpha1.KubeconfigSpec
+		labels          map[string]string
+		wantEnvironment string
+		wantProvider    string
+		wantOwner       string
+	}{
+		{name: "no metadata"},
+		{
+			name:            "spec",
+			spec:            v1alpha1.KubeconfigSpec{Environment: "prod", Provider: "aws", Owner: "platform"},
+			labels:          map[string]string{environmentLabel: "dev", providerLabel: "gcp", ownerLabel: "data"},
+			wantEnvironment: "prod",
+			wantProvider:    "aws",
+			wantOwner:       "platform",
+		},
+		{
+			name:            "labels",
+			namespace:       "team-a",
+			labels:          map[string]string{environmentLabel: "dev", providerLabel: "gcp", ownerLabel: "data"},
+			wantEnvironment: "dev",
+			wantProvider:    "gcp",
+			wantOwner:       "data",
+		},
+		{name: "team of the namespace", namespace: "team-a", wantOwner: "alpha"},
+		{name: "namespace without team", namespace: "team-b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfig := &v1alpha1.Kubeconfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Name: "prod", Labels: tt.labels},
+				Spec:       tt.spec,
+			}
+
+			if got := kubeconfigEnvironment(kubeconfig); got != tt.wantEnvironment {
+				t.Errorf("kubeconfigEnvironment() = %q, want %q", got, tt.wantEnvironment)
+			}
+			if got := kubeconfigProvider(kubeconfig); got != tt.wantProvider {
+				t.Errorf("kubeconfigProvider() = %q, want %q", got, tt.wantProvider)
+			}
+			if got := kubeconfigOwner(kubeconfig); got != tt.wantOwner {
+				t.Errorf("kubeconfigOwner() = %q, want %q", got, tt.wantOwner)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/yaml"
+)
+
+// Converts Kubeconfig data to the client-go representation of a kubeconfig
+func toClientcmdConfig(data *v1alpha1.KubeconfigData) (*clientcmdapi.Config, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.Load(raw)
+}
+
+// Converts the client-go representation of a kubeconfig to Kubeconfig data
+func fromClientcmdConfig(config *clientcmdapi.Config) (*v1alpha1.KubeconfigData, error) {
+	raw, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, err
+	}
+	var data v1alpha1.KubeconfigData
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Loads the clusters and contexts of a kubeconfig, dropping its users so their credentials are
+// never handed out
+func loadWithoutCredentials(raw []byte) (*v1alpha1.KubeconfigData, error) {
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		return nil, err
+	}
+	config.AuthInfos = nil
+	for _, context := range config.Contexts {
+		context.AuthInfo = ""
+	}
+	return fromClientcmdConfig(config)
+}
+
+// Checks that client-go accepts rendered Kubeconfig data. certificate-authority points to a file
+// on the machine of the user, so it is not checked here.
+func validateRendered(data *v1alpha1.KubeconfigData) error {
+	config, err := toClientcmdConfig(data)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range config.Clusters {
+		cluster.CertificateAuthority = ""
+	}
+	return clientcmd.Validate(*config)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Returns Kubeconfig data using every field of the clusters and contexts
+func testFullKubeconfigData() *v1alpha1.KubeconfigData {
+	extension := []v1alpha1.NamedExtension{{
+		Name:      "kubebrowser.io/cluster-info",
+		Extension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"info"}}`)},
+	}}
+	return &v1alpha1.KubeconfigData{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []v1alpha1.Cluster{{
+			Name: "prod",
+			Cluster: v1alpha1.Details{
+				Server:                   "https://prod.example.com",
+				CertificateAuthorityData: "Y2EtZGF0YQ==",
+				TLSServerName:            "api.prod.example.com",
+				ProxyURL:                 "http://proxy.example.com:3128",
+				DisableCompression:       true,
+				Extensions:               extension,
+			},
+		}, {
+			Name: "staging",
+			Cluster: v1alpha1.Details{
+				Server:               "https://staging.example.com",
+				CertificateAuthority: "/etc/kubernetes/staging-ca.crt",
+			},
+		}},
+		Contexts: []v1alpha1.Context{{
+			Name:    "prod",
+			Context: v1alpha1.ContextSpec{Cluster: "prod", User: "oidc", Namespace: "apps", Extensions: extension},
+		}, {
+			Name:    "staging",
+			Context: v1alpha1.ContextSpec{Cluster: "staging", User: "oidc"},
+		}},
+		CurrentContext: "prod",
+		Users: []v1alpha1.User{{
+			Name: "oidc",
+			User: v1alpha1.UserSpec{Exec: &v1alpha1.ExecConfig{
+				APIVersion:      "client.authentication.k8s.io/v1beta1",
+				Command:         "kubectl",
+				Args:            []string{"oidc-login", "get-token"},
+				InteractiveMode: "IfAvailable",
+			}},
+		}},
+	}
+}
+
+func TestClientcmdConfigRoundTrip(t *testing.T) {
+	minimal := &v1alpha1.KubeconfigData{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters:   []v1alpha1.Cluster{{Name: "dev", Cluster: v1alpha1.Details{Server: "https://dev.example.com"}}},
+		Contexts:   []v1alpha1.Context{{Name: "dev", Context: v1alpha1.ContextSpec{Cluster: "dev"}}},
+	}
+
+	tests := []struct {
+		name string
+		data *v1alpha1.KubeconfigData
+	}{
+		{name: "minimal", data: minimal},
+		{name: "every field", data: testFullKubeconfigData()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := toClientcmdConfig(tt.data)
+			if err != nil {
+				t.Fatalf("toClientcmdConfig() error = %v", err)
+			}
+			got, err := fromClientcmdConfig(config)
+			if err != nil {
+				t.Fatalf("fromClientcmdConfig() error = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(got, tt.data) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestToClientcmdConfig(t *testing.T) {
+	config, err := toClientcmdConfig(testFullKubeconfigData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := config.Clusters["prod"]
+	if cluster == nil || string(cluster.CertificateAuthorityData) != "ca-data" || cluster.TLSServerName != "api.prod.example.com" ||
+		cluster.ProxyURL != "http://proxy.example.com:3128" || !cluster.DisableCompression || len(cluster.Extensions) != 1 {
+		t.Errorf("cluster = %+v, want every field of the prod cluster", cluster)
+	}
+	if staging := config.Clusters["staging"]; staging == nil || staging.CertificateAuthority != "/etc/kubernetes/staging-ca.crt" {
+		t.Errorf("cluster = %+v, want the certificate authority path", staging)
+	}
+	if context := config.Contexts["prod"]; context == nil || context.Namespace != "apps" || len(context.Extensions) != 1 {
+		t.Errorf("context = %+v, want its namespace and extension", context)
+	}
+	if user := config.AuthInfos["oidc"]; user == nil || user.Exec == nil || user.Exec.Command != "kubectl" {
+		t.Errorf("user = %+v, want the exec credentials", user)
+	}
+}
+
+func TestLoadWithoutCredentials(t *testing.T) {
+	raw := []byte(`apiVersion: v1
+kind: Config
+clusters:
+  - name: prod
+    cluster:
+      server: https://prod.example.com
+      tls-server-name: api.prod.example.com
+contexts:
+  - name: prod
+    context:
+      cluster: prod
+      user: admin
+      namespace: apps
+current-context: prod
+users:
+  - name: admin
+    user:
+      token: secret
+`)
+
+	data, err := loadWithoutCredentials(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Users) != 0 || data.Contexts[0].Context.User != "" {
+		t.Errorf("loadWithoutCredentials() = %+v, want no users", data)
+	}
+	if data.Clusters[0].Cluster.TLSServerName != "api.prod.example.com" || data.Contexts[0].Context.Namespace != "apps" {
+		t.Errorf("loadWithoutCredentials() = %+v, want the clusters and contexts", data)
+	}
+}
+
+func TestValidateRendered(t *testing.T) {
+	unknownCluster := testFullKubeconfigData()
+	unknownCluster.Contexts[1].Context.Cluster = "unknown"
+	unknownUser := testFullKubeconfigData()
+	unknownUser.Contexts[0].Context.User = "unknown"
+
+	tests := []struct {
+		name    string
+		data    *v1alpha1.KubeconfigData
+		wantErr bool
+	}{
+		// The certificate authority file of the staging cluster is on the machine of the user
+		{name: "every field", data: testFullKubeconfigData()},
+		{name: "unknown cluster", data: unknownCluster, wantErr: true},
+		{name: "unknown user", data: unknownUser, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRendered(tt.data); (err != nil) != tt.wantErr {
+				t.Errorf("validateRendered() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
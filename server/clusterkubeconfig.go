@@ -0,0 +1,15 @@
+package main
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+)
+
+// Returns a ClusterKubeconfig as a Kubeconfig without namespace
+func fromClusterKubeconfig(clusterKubeconfig *v1alpha1.ClusterKubeconfig) *v1alpha1.Kubeconfig {
+	clusterKubeconfig = clusterKubeconfig.DeepCopy()
+	return &v1alpha1.Kubeconfig{
+		TypeMeta:   clusterKubeconfig.TypeMeta,
+		ObjectMeta: clusterKubeconfig.ObjectMeta,
+		Spec:       clusterKubeconfig.Spec,
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFromClusterKubeconfig(t *testing.T) {
+	clusterKubeconfig := &v1alpha1.ClusterKubeconfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ClusterKubeconfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Labels: map[string]string{"env": "prod"}},
+		Spec:       testKubeconfigSpec("Shared"),
+	}
+
+	kubeconfig := fromClusterKubeconfig(clusterKubeconfig)
+	if kubeconfig.Namespace != "" || kubeconfig.Name != "shared" || kubeconfig.Kind != "ClusterKubeconfig" {
+		t.Errorf("fromClusterKubeconfig() = %+v, want the ClusterKubeconfig without namespace", kubeconfig.ObjectMeta)
+	}
+	if !equality.Semantic.DeepEqual(kubeconfig.Spec, clusterKubeconfig.Spec) {
+		t.Errorf("Spec = %+v, want %+v", kubeconfig.Spec, clusterKubeconfig.Spec)
+	}
+
+	kubeconfig.Labels["env"] = "dev"
+	kubeconfig.Spec.Kubeconfig.Clusters[0].Cluster.Server = "https://other.example.com"
+	if clusterKubeconfig.Labels["env"] != "prod" || clusterKubeconfig.Spec.Kubeconfig.Clusters[0].Cluster.Server == "https://other.example.com" {
+		t.Error("fromClusterKubeconfig() shares its metadata or spec with the cached ClusterKubeconfig")
+	}
+}
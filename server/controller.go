@@ -3,26 +3,131 @@ package main
 import (
 	"context"
 	"errors"
-	"time"
+	"fmt"
+	"os"
 
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
 	clientset "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned"
+	"github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/scheme"
 	informers "github.com/AvistoTelecom/kubebrowser/pkg/client/informers/externalversions"
 	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
 	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 )
 
 var kubecfg = &Kubecfg{}
 
 type Kubecfg struct {
-	lister v1alpha1.KubeconfigLister
+	catalog       Catalog
+	client        clientset.Interface
+	kubeClient    kubernetes.Interface
+	lister        v1alpha1.KubeconfigLister
+	requestLister v1alpha1.AccessRequestLister
+	grantLister   v1alpha1.AccessGrantLister
+	aliasLister   v1alpha1.GroupAliasLister
+	clusterLister v1alpha1.ClusterKubeconfigLister
+	// Announcements of the pod namespace
+	announcementLister v1alpha1.AnnouncementLister
+	// Set when Kubeconfigs are watched in other namespaces than the pod namespace
+	namespaceLister   corelisters.NamespaceLister
+	namespaceSelector labels.Selector
+	// Secrets and ConfigMaps labeled as Kubeconfig sources
+	secretLister    corelisters.SecretLister
+	configMapLister corelisters.ConfigMapLister
+	// Set when Kubeconfigs are imported from Cluster API
+	capiLister       cache.GenericLister
+	capiSecretLister corelisters.SecretLister
+	// Set when Kubeconfigs are imported from Argo CD
+	argoCDSecretLister corelisters.SecretLister
+	recorder           record.EventRecorder
+	reconciler         *Reconciler
 }
 
-// Setup the Kubernetes client and the SharedInformerFactory
-// Returns a KubeconfigLister
+// Returns the configuration of the cluster, from the kubeconfig given with --kubeconfig or the
+// KUBECONFIG variable, then from the in-cluster configuration, then from ~/.kube/config.
+// Out of the cluster, the pod namespace defaults to the namespace of the kubeconfig context.
+func restConfig() (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = viper.GetString(kubeconfigKey)
+	if loadingRules.ExplicitPath == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" {
+		cfg, err := rest.InClusterConfig()
+		if !errors.Is(err, rest.ErrNotInCluster) {
+			return cfg, err
+		}
+		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err != nil {
+			return nil, rest.ErrNotInCluster
+		}
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: viper.GetString(kubeContextKey)},
+	)
+	cfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if viper.GetString(podNamespaceKey) == "" {
+		namespace, _, err := clientConfig.Namespace()
+		if err != nil {
+			return nil, err
+		}
+		viper.Set(podNamespaceKey, namespace)
+	}
+	logger.Infow("Using kubeconfig", "context", viper.GetString(kubeContextKey), "namespace", viper.GetString(podNamespaceKey))
+	return cfg, nil
+}
+
+// Setup the catalog, the Kubernetes client and the SharedInformerFactory. Without a cluster, only
+// the directory, git and federated catalogs are available.
 func (k *Kubecfg) Init(ctx context.Context) error {
-	cfg, err := rest.InClusterConfig()
+	provider := viper.GetString(catalogProviderKey)
+	switch provider {
+	case catalogProviderKubernetes:
+		k.catalog = kubernetesCatalog{}
+	case catalogProviderDirectory:
+		catalog, err := newDirectoryCatalog(ctx, viper.GetString(catalogDirectoryKey))
+		if err != nil {
+			return err
+		}
+		k.catalog = catalog
+	case catalogProviderGit:
+		catalog, err := newGitCatalog(
+			ctx,
+			viper.GetString(catalogGitURLKey),
+			viper.GetString(catalogGitRefKey),
+			viper.GetString(catalogGitPathKey),
+			viper.GetDuration(catalogGitIntervalKey),
+		)
+		if err != nil {
+			return err
+		}
+		k.catalog = catalog
+	case catalogProviderFederated:
+		catalog, err := newFederatedCatalog(ctx, viper.GetString(catalogSourcesKey))
+		if err != nil {
+			return err
+		}
+		k.catalog = catalog
+	default:
+		return fmt.Errorf("unknown catalog provider %q", provider)
+	}
+
+	cfg, err := restConfig()
+	if errors.Is(err, rest.ErrNotInCluster) && provider != catalogProviderKubernetes {
+		logger.Warn("Not connected to a cluster, access requests, break-glass and group aliases are disabled")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -31,21 +136,163 @@ func (k *Kubecfg) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	k.client = exampleClient
+
+	// Record Kubernetes Events on our resources and on the objects clusters are imported from, in
+	// their own namespace
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	k.kubeClient = kubeClient
+	eventBroadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: kubeClient.CoreV1().Events(metav1.NamespaceAll),
+	})
+	k.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kubebrowser"})
 
 	// Create the namespace-scoped informer factory
 	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(
 		exampleClient,
-		time.Second*30,
+		viper.GetDuration(informerResyncKey),
 		informers.WithNamespace(viper.GetString(podNamespaceKey)),
 	)
 
-	k.lister = kubeInformerFactory.Kubeconfig().V1alpha1().Kubeconfigs().Lister()
+	kubeconfigInformer := kubeInformerFactory.Kubeconfig().V1alpha1().Kubeconfigs()
+	requestInformer := kubeInformerFactory.Kubeconfig().V1alpha1().AccessRequests()
+	grantInformer := kubeInformerFactory.Kubeconfig().V1alpha1().AccessGrants()
+	aliasInformer := kubeInformerFactory.Kubeconfig().V1alpha1().GroupAliases()
+	clusterInformer := kubeInformerFactory.Kubeconfig().V1alpha1().ClusterKubeconfigs()
+	announcementInformer := kubeInformerFactory.Kubeconfig().V1alpha1().Announcements()
+	synced := []cache.InformerSynced{}
+
+	// Watch Kubeconfigs in all namespaces, listers filter the ones of the watched namespaces
+	var allNamespacesInformerFactory informers.SharedInformerFactory
+	var namespaceInformerFactory kubeinformers.SharedInformerFactory
+	clusterNamespace := viper.GetString(podNamespaceKey)
+	if watchesMultipleNamespaces() {
+		clusterNamespace = metav1.NamespaceAll
+		if selector := viper.GetString(watchNamespaceSelectorKey); selector != "" {
+			k.namespaceSelector, err = labels.Parse(selector)
+			if err != nil {
+				return err
+			}
+		}
+
+		allNamespacesInformerFactory = informers.NewSharedInformerFactory(exampleClient, viper.GetDuration(informerResyncKey))
+		kubeconfigInformer = allNamespacesInformerFactory.Kubeconfig().V1alpha1().Kubeconfigs()
+
+		namespaceInformerFactory = kubeinformers.NewSharedInformerFactory(kubeClient, viper.GetDuration(informerResyncKey))
+		namespaceInformer := namespaceInformerFactory.Core().V1().Namespaces()
+		k.namespaceLister = namespaceInformer.Lister()
+		if err := publishCatalogChanges(namespaceInformer.Informer(), nil); err != nil {
+			return err
+		}
+		synced = append(synced, namespaceInformer.Informer().HasSynced)
+	}
+
+	// Watch the Secrets and ConfigMaps Kubeconfigs can source their clusters from, with an informer
+	// per namespace unless they are readable cluster-wide
+	secretListers, configMapListers := namespacedSecretLister{}, namespacedConfigMapLister{}
+	var sourceInformerFactories []kubeinformers.SharedInformerFactory
+	var sourceInformers []cache.SharedIndexInformer
+	for _, namespace := range sourceNamespaces() {
+		factory := kubeinformers.NewSharedInformerFactoryWithOptions(
+			kubeClient,
+			viper.GetDuration(informerResyncKey),
+			kubeinformers.WithNamespace(namespace),
+			kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.LabelSelector = kubeconfigSourceLabel + "=true"
+			}),
+		)
+		secretInformer := factory.Core().V1().Secrets()
+		configMapInformer := factory.Core().V1().ConfigMaps()
+		secretListers[namespace] = secretInformer.Lister()
+		configMapListers[namespace] = configMapInformer.Lister()
+		sourceInformerFactories = append(sourceInformerFactories, factory)
+		sourceInformers = append(sourceInformers, secretInformer.Informer(), configMapInformer.Informer())
+	}
+	k.secretLister = secretListers
+	k.configMapLister = configMapListers
+
+	k.lister = kubeconfigInformer.Lister()
+	k.requestLister = requestInformer.Lister()
+	k.grantLister = grantInformer.Lister()
+	k.aliasLister = aliasInformer.Lister()
+	k.clusterLister = clusterInformer.Lister()
+	k.announcementLister = announcementInformer.Lister()
+
+	k.reconciler, err = NewReconciler(kubeconfigInformer.Informer())
+	if err != nil {
+		return err
+	}
+	if err := k.reconciler.watchSources(sourceInformers...); err != nil {
+		return err
+	}
+
+	// Push the changes of the catalog to the event streams of the users
+	if err := publishCatalogChanges(kubeconfigInformer.Informer(), kubeconfigObjectID); err != nil {
+		return err
+	}
+	if err := publishCatalogChanges(clusterInformer.Informer(), func(obj any) []string {
+		return []string{obj.(*kubeconfigv1alpha1.ClusterKubeconfig).Name}
+	}); err != nil {
+		return err
+	}
+	if err := publishCatalogChanges(grantInformer.Informer(), func(obj any) []string {
+		return []string{obj.(*kubeconfigv1alpha1.AccessGrant).Spec.Kubeconfig}
+	}); err != nil {
+		return err
+	}
+	for _, informer := range sourceInformers {
+		if err := publishCatalogChanges(informer, nil); err != nil {
+			return err
+		}
+	}
+
+	if viper.GetBool(capiEnabledKey) {
+		capiSynced, err := k.initCAPI(ctx, cfg, clusterNamespace)
+		if err != nil {
+			return err
+		}
+		synced = append(synced, capiSynced...)
+	}
+	if viper.GetBool(argoCDEnabledKey) {
+		argoCDSynced, err := k.initArgoCD(ctx)
+		if err != nil {
+			return err
+		}
+		synced = append(synced, argoCDSynced...)
+	}
 
 	kubeInformerFactory.Start(ctx.Done())
+	for _, factory := range sourceInformerFactories {
+		factory.Start(ctx.Done())
+	}
+	if allNamespacesInformerFactory != nil {
+		allNamespacesInformerFactory.Start(ctx.Done())
+		namespaceInformerFactory.Start(ctx.Done())
+	}
 
-	if !cache.WaitForCacheSync(ctx.Done(), kubeInformerFactory.Kubeconfig().V1alpha1().Kubeconfigs().Informer().HasSynced) {
+	synced = append(synced,
+		kubeconfigInformer.Informer().HasSynced,
+		requestInformer.Informer().HasSynced,
+		grantInformer.Informer().HasSynced,
+		aliasInformer.Informer().HasSynced,
+		clusterInformer.Informer().HasSynced,
+		announcementInformer.Informer().HasSynced,
+	)
+	for _, informer := range sourceInformers {
+		synced = append(synced, informer.HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
 		return errors.New("failed to sync caches")
 	}
 
 	return nil
 }
+
+// Returns whether Kubebrowser is connected to a cluster
+func (k *Kubecfg) HasCluster() bool {
+	return k.client != nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const convertKubeconfigRoute = "/convert"
+
+// Subset of the apiextensions.k8s.io/v1 ConversionReview used by the conversion webhook
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+func handleConvertKubeconfig(c *gin.Context) {
+	var review conversionReview
+	if err := c.ShouldBindJSON(&review); err != nil || review.Request == nil {
+		c.String(http.StatusBadRequest, "Invalid conversion review")
+		return
+	}
+
+	response := &conversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, object := range review.Request.Objects {
+		converted, err := convertKubeconfig(object.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			logger.Errorf("Error converting kubeconfig: %s", err)
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	review.Request = nil
+	review.Response = response
+	c.JSON(http.StatusOK, review)
+}
+
+// Converts a raw Kubeconfig object between v1alpha1 and v1beta1
+func convertKubeconfig(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	switch {
+	case typeMeta.APIVersion == v1alpha1.SchemeGroupVersion.String() && desiredAPIVersion == v1beta1.SchemeGroupVersion.String():
+		var in v1alpha1.Kubeconfig
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, err
+		}
+		var out v1beta1.Kubeconfig
+		if err := v1beta1.ConvertFromV1alpha1(&in, &out); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&out)
+	case typeMeta.APIVersion == v1beta1.SchemeGroupVersion.String() && desiredAPIVersion == v1alpha1.SchemeGroupVersion.String():
+		var in v1beta1.Kubeconfig
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, err
+		}
+		var out v1alpha1.Kubeconfig
+		if err := v1beta1.ConvertToV1alpha1(&in, &out); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&out)
+	}
+	return nil, fmt.Errorf("cannot convert %s to %s", typeMeta.APIVersion, desiredAPIVersion)
+}
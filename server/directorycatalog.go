@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
+	"github.com/fsnotify/fsnotify"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Delay between the last change in the directory and its reload, so a batch of changes is loaded
+// at once
+const directoryReloadDelay = 500 * time.Millisecond
+
+// Catalog serving an immutable set of Kubeconfigs, replaced at once on reload
+type snapshotCatalog struct {
+	kubeconfigs atomic.Pointer[map[string]*v1alpha1.Kubeconfig]
+}
+
+func (c *snapshotCatalog) List() ([]*v1alpha1.Kubeconfig, error) {
+	kubeconfigs := *c.kubeconfigs.Load()
+	list := make([]*v1alpha1.Kubeconfig, 0, len(kubeconfigs))
+	for _, kubeconfig := range kubeconfigs {
+		list = append(list, kubeconfig)
+	}
+	slices.SortFunc(list, func(a, b *v1alpha1.Kubeconfig) int { return strings.Compare(a.Name, b.Name) })
+	return list, nil
+}
+
+func (c *snapshotCatalog) Get(id string) (*v1alpha1.Kubeconfig, error) {
+	kubeconfig, ok := (*c.kubeconfigs.Load())[id]
+	if !ok {
+		return nil, apierrors.NewNotFound(v1alpha1.Resource("kubeconfigs"), id)
+	}
+	return kubeconfig, nil
+}
+
+// Catalog of the Kubeconfig manifests of a directory, reloaded when the directory changes
+type directoryCatalog struct {
+	snapshotCatalog
+	dir string
+}
+
+// Loads the Kubeconfig manifests of the directory and watches it until the context is cancelled
+func newDirectoryCatalog(ctx context.Context, dir string) (*directoryCatalog, error) {
+	c := &directoryCatalog{dir: dir}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	go c.watch(ctx, watcher)
+
+	return c, nil
+}
+
+func (c *directoryCatalog) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			logger.Debugw("Kubeconfig directory changed", "event", event.String())
+			reload = time.After(directoryReloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Errorf("Error watching kubeconfig directory: %s", err)
+		case <-reload:
+			reload = nil
+			if err := c.reload(); err != nil {
+				logger.Errorf("Error reloading kubeconfig directory, keeping the previous catalog: %s", err)
+			}
+		}
+	}
+}
+
+// Loads every manifest of the directory, then replaces the whole catalog. The catalog is left
+// untouched if any manifest cannot be loaded.
+func (c *directoryCatalog) reload() error {
+	kubeconfigs, err := loadKubeconfigDirectory(c.dir)
+	if err != nil {
+		return err
+	}
+
+	c.kubeconfigs.Store(&kubeconfigs)
+	catalogChanges.publish()
+	logger.Infow("Loaded kubeconfig directory", "dir", c.dir, "kubeconfigs", len(kubeconfigs))
+	return nil
+}
+
+// Loads the Kubeconfig manifests of the YAML and JSON files of a directory, by name
+func loadKubeconfigDirectory(dir string) (map[string]*v1alpha1.Kubeconfig, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigs := make(map[string]*v1alpha1.Kubeconfig)
+	for _, entry := range entries {
+		// Also skips the ..data links of mounted ConfigMaps
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		loaded, err := loadKubeconfigManifests(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		for _, kubeconfig := range loaded {
+			if _, ok := kubeconfigs[kubeconfig.Name]; ok {
+				return nil, fmt.Errorf("%s: duplicate kubeconfig %s", entry.Name(), kubeconfig.Name)
+			}
+			kubeconfigs[kubeconfig.Name] = kubeconfig
+		}
+	}
+	return kubeconfigs, nil
+}
+
+// Decodes the Kubeconfigs and ClusterKubeconfigs of a YAML or JSON file, skipping other kinds and
+// the Kubeconfigs sourced from Secrets or ConfigMaps
+func loadKubeconfigManifests(path string) ([]*v1alpha1.Kubeconfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var kubeconfigs []*v1alpha1.Kubeconfig
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := json.Unmarshal(raw, &typeMeta); err != nil {
+			return nil, err
+		}
+
+		kubeconfig := &v1alpha1.Kubeconfig{}
+		switch {
+		case typeMeta.APIVersion == v1alpha1.SchemeGroupVersion.String() &&
+			(typeMeta.Kind == "Kubeconfig" || typeMeta.Kind == "ClusterKubeconfig"):
+			if err := json.Unmarshal(raw, kubeconfig); err != nil {
+				return nil, err
+			}
+		case typeMeta.APIVersion == v1beta1.SchemeGroupVersion.String() && typeMeta.Kind == "Kubeconfig":
+			var in v1beta1.Kubeconfig
+			if err := json.Unmarshal(raw, &in); err != nil {
+				return nil, err
+			}
+			if err := v1beta1.ConvertToV1alpha1(&in, kubeconfig); err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+
+		if kubeconfig.Name == "" {
+			return nil, errors.New("kubeconfig without name")
+		}
+		if kubeconfig.Spec.KubeconfigFrom != nil {
+			logger.Warnw("Skipping kubeconfig using kubeconfigFrom, which needs a cluster", "path", path, "name", kubeconfig.Name)
+			continue
+		}
+		// Kubeconfigs of a directory are identified by their name only
+		kubeconfig.TypeMeta = metav1.TypeMeta{}
+		kubeconfig.Namespace = ""
+		kubeconfigs = append(kubeconfigs, kubeconfig)
+	}
+	return kubeconfigs, nil
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testV1alpha1Manifests = `apiVersion: kubebrowser.io/v1alpha1
+kind: Kubeconfig
+metadata:
+  name: prod
+  namespace: kubebrowser
+spec:
+  name: Production
+  kubeconfig:
+    clusters:
+      - name: prod
+        cluster:
+          server: https://prod.example.com
+---
+apiVersion: kubebrowser.io/v1alpha1
+kind: ClusterKubeconfig
+metadata:
+  name: shared
+spec:
+  name: Shared
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+---
+apiVersion: kubebrowser.io/v1alpha1
+kind: Kubeconfig
+metadata:
+  name: sourced
+spec:
+  name: Sourced
+  kubeconfigFrom:
+    secretRef:
+      name: sourced
+`
+
+const testV1beta1Manifest = `apiVersion: kubebrowser.io/v1beta1
+kind: Kubeconfig
+metadata:
+  name: staging
+spec:
+  display:
+    name: Staging
+  clusters:
+    - name: staging
+      cluster:
+        server: https://staging.example.com
+  contexts:
+    - name: staging
+      context:
+        cluster: staging
+  access:
+    whitelist:
+      groups:
+        - platform-team
+`
+
+// Writes the files in a new directory and returns its path
+func testKubeconfigDirectory(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadKubeconfigManifests(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantNames []string
+		wantErr   bool
+	}{
+		{name: "v1alpha1 manifests", file: "catalog.yaml", content: testV1alpha1Manifests, wantNames: []string{"prod", "shared"}},
+		{name: "v1beta1 manifest", file: "staging.yaml", content: testV1beta1Manifest, wantNames: []string{"staging"}},
+		{
+			name:      "JSON manifest",
+			file:      "dev.json",
+			content:   `{"apiVersion": "kubebrowser.io/v1alpha1", "kind": "Kubeconfig", "metadata": {"name": "dev"}, "spec": {"name": "Development"}}`,
+			wantNames: []string{"dev"},
+		},
+		{name: "empty documents", file: "empty.yaml", content: "---\n---\n"},
+		{name: "kubeconfig without name", file: "unnamed.yaml", content: "apiVersion: kubebrowser.io/v1alpha1\nkind: Kubeconfig\nspec:\n  name: Unnamed\n", wantErr: true},
+		{name: "invalid YAML", file: "invalid.yaml", content: "apiVersion: [", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testKubeconfigDirectory(t, map[string]string{tt.file: tt.content})
+
+			kubeconfigs, err := loadKubeconfigManifests(filepath.Join(dir, tt.file))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadKubeconfigManifests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, kubeconfig := range kubeconfigs {
+				names = append(names, kubeconfig.Name)
+				if kubeconfig.Namespace != "" || kubeconfig.Kind != "" {
+					t.Errorf("%s keeps its namespace %q or kind %q", kubeconfig.Name, kubeconfig.Namespace, kubeconfig.Kind)
+				}
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("loadKubeconfigManifests() = %q, want %q", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestLoadKubeconfigManifestsConvertsV1beta1(t *testing.T) {
+	dir := testKubeconfigDirectory(t, map[string]string{"staging.yaml": testV1beta1Manifest})
+
+	kubeconfigs, err := loadKubeconfigManifests(filepath.Join(dir, "staging.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := kubeconfigs[0].Spec
+	if spec.Name != "Staging" || spec.Kubeconfig.Clusters[0].Cluster.Server != "https://staging.example.com" {
+		t.Errorf("spec = %+v, want the display name and cluster of the v1beta1 manifest", spec)
+	}
+	if spec.Whitelist == nil || !slices.Equal(spec.Whitelist.Groups, []string{"platform-team"}) {
+		t.Errorf("whitelist = %+v, want the platform-team group", spec.Whitelist)
+	}
+}
+
+func TestLoadKubeconfigDirectory(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name: "manifests",
+			files: map[string]string{
+				"catalog.yaml":  testV1alpha1Manifests,
+				"staging.yml":   testV1beta1Manifest,
+				"README.md":     "not a manifest",
+				".hidden.yaml":  "apiVersion: [",
+				"..data.yaml":   "apiVersion: [",
+				"notes.txt.bak": "apiVersion: [",
+			},
+			wantNames: []string{"prod", "shared", "staging"},
+		},
+		{
+			name:    "invalid manifest",
+			files:   map[string]string{"catalog.yaml": testV1alpha1Manifests, "broken.yaml": "apiVersion: ["},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			files:   map[string]string{"catalog.yaml": testV1alpha1Manifests, "copy.yaml": testV1alpha1Manifests},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testKubeconfigDirectory(t, tt.files)
+			if err := os.Mkdir(filepath.Join(dir, "nested.yaml"), 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			kubeconfigs, err := loadKubeconfigDirectory(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadKubeconfigDirectory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for name := range kubeconfigs {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("loadKubeconfigDirectory() = %q, want %q", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestDirectoryCatalogReloadKeepsLastGoodSnapshot(t *testing.T) {
+	dir := testKubeconfigDirectory(t, map[string]string{"catalog.yaml": testV1alpha1Manifests})
+	catalog := &directoryCatalog{dir: dir}
+	if err := catalog.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("apiVersion: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.reload(); err == nil {
+		t.Fatal("reload() succeeded with an invalid manifest")
+	}
+	if _, err := catalog.Get("prod"); err != nil {
+		t.Errorf("Get() error = %v, want the previous snapshot", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte(testV1beta1Manifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.reload(); err != nil {
+		t.Fatal(err)
+	}
+	kubeconfigs, err := catalog.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, kubeconfig := range kubeconfigs {
+		names = append(names, kubeconfig.Name)
+	}
+	if want := []string{"prod", "shared", "staging"}; !slices.Equal(names, want) {
+		t.Errorf("List() = %q, want %q", names, want)
+	}
+}
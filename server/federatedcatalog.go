@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	clientset "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned"
+	informers "github.com/AvistoTelecom/kubebrowser/pkg/client/informers/externalversions"
+	listers "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// Label set on the Kubeconfigs of the federated catalog, naming their source cluster
+	catalogSourceLabel = "kubebrowser.io/catalog-source"
+	// Annotation set on the Kubeconfigs of a source cluster that cannot be reached
+	staleAnnotation = "kubebrowser.io/stale"
+)
+
+// Catalog merging the Kubeconfigs of several source clusters. The Kubeconfigs of a source are
+// identified by <source>:<name>. When a source cannot be reached, its last known Kubeconfigs are
+// still served, marked stale.
+type federatedCatalog struct {
+	sources []*catalogSource
+}
+
+// Source cluster of the federated catalog, serving the Kubeconfigs of the namespace of its
+// kubeconfig context
+type catalogSource struct {
+	name      string
+	namespace string
+	client    clientset.Interface
+	lister    listers.KubeconfigLister
+	synced    cache.InformerSynced
+
+	mu     sync.Mutex
+	status CatalogSourceStatus
+}
+
+// Watches the source clusters, given as name=kubeconfig path pairs, until the context is
+// cancelled. Sources are not waited for, so that a source being down does not block the server.
+func newFederatedCatalog(ctx context.Context, sources string) (*federatedCatalog, error) {
+	c := &federatedCatalog{}
+	for _, source := range splitList(sources) {
+		name, path, ok := strings.Cut(source, "=")
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid catalog source %q, expected name=kubeconfig path", source)
+		}
+		if name+":" == capiIDPrefix || name+":" == argoCDIDPrefix || strings.ContainsAny(name, ":/") {
+			return nil, fmt.Errorf("invalid catalog source name %q", name)
+		}
+		if slices.ContainsFunc(c.sources, func(s *catalogSource) bool { return s.name == name }) {
+			return nil, fmt.Errorf("duplicate catalog source %q", name)
+		}
+
+		s, err := newCatalogSource(ctx, name, path)
+		if err != nil {
+			return nil, fmt.Errorf("catalog source %s: %w", name, err)
+		}
+		c.sources = append(c.sources, s)
+	}
+	if len(c.sources) == 0 {
+		return nil, fmt.Errorf("%s is required with the federated catalog provider", catalogSourcesKey)
+	}
+	return c, nil
+}
+
+func newCatalogSource(ctx context.Context, name, path string) (*catalogSource, error) {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: path},
+		&clientcmd.ConfigOverrides{},
+	)
+	cfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &catalogSource{
+		name:      name,
+		namespace: namespace,
+		client:    client,
+		status:    CatalogSourceStatus{Name: name},
+	}
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(
+		client,
+		viper.GetDuration(informerResyncKey),
+		informers.WithNamespace(namespace),
+	)
+	informer := informerFactory.Kubeconfig().V1alpha1().Kubeconfigs()
+	// Without probes, a source marked stale would never recover
+	probeInterval := viper.GetDuration(catalogSourceProbeIntervalKey)
+	if probeInterval > 0 {
+		if err := informer.Informer().SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+			s.setError(err)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := publishCatalogChanges(informer.Informer(), func(obj any) []string {
+		return []string{name + ":" + obj.(*v1alpha1.Kubeconfig).Name}
+	}); err != nil {
+		return nil, err
+	}
+	s.lister = informer.Lister()
+	s.synced = informer.Informer().HasSynced
+
+	informerFactory.Start(ctx.Done())
+	if probeInterval > 0 {
+		go s.probe(ctx, probeInterval)
+	}
+	return s, nil
+}
+
+// Checks that the source answers every interval, as the informer only reports failures
+func (s *catalogSource) probe(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Requests a Kubeconfig of the source, recording whether it answered
+func (s *catalogSource) check(ctx context.Context) {
+	_, err := s.client.KubeconfigV1alpha1().Kubeconfigs(s.namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		s.setError(err)
+		return
+	}
+
+	s.mu.Lock()
+	now := time.Now()
+	s.status.LastSyncTime = &now
+	recovered := s.status.LastError != ""
+	s.status.LastError = ""
+	s.mu.Unlock()
+	if recovered {
+		catalogChanges.publish()
+	}
+}
+
+func (s *catalogSource) setError(err error) {
+	logger.Warnw("Catalog source cannot be reached, serving its last known kubeconfigs", "source", s.name, "error", err)
+	s.mu.Lock()
+	failed := s.status.LastError == ""
+	s.status.LastError = err.Error()
+	s.mu.Unlock()
+	// The Kubeconfigs of the source are now stale
+	if failed {
+		catalogChanges.publish()
+	}
+}
+
+func (s *catalogSource) Status() CatalogSourceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.status
+	status.Synced = s.synced()
+	status.Stale = status.LastError != ""
+	return status
+}
+
+// Returns a copy of the Kubeconfig labeled with the source, and annotated when stale
+func (s *catalogSource) mark(kubeconfig *v1alpha1.Kubeconfig, stale bool) *v1alpha1.Kubeconfig {
+	kubeconfig = kubeconfig.DeepCopy()
+	if kubeconfig.Labels == nil {
+		kubeconfig.Labels = map[string]string{}
+	}
+	kubeconfig.Labels[catalogSourceLabel] = s.name
+	if stale {
+		if kubeconfig.Annotations == nil {
+			kubeconfig.Annotations = map[string]string{}
+		}
+		kubeconfig.Annotations[staleAnnotation] = "true"
+	}
+	return kubeconfig
+}
+
+// Lists the Kubeconfigs of every source. Kubeconfigs sourced from Secrets or ConfigMaps are
+// skipped, as the Secrets and ConfigMaps of the sources are not watched.
+func (c *federatedCatalog) List() ([]*v1alpha1.Kubeconfig, error) {
+	var kubeconfigs []*v1alpha1.Kubeconfig
+	for _, s := range c.sources {
+		status := s.Status()
+		if !status.Synced {
+			continue
+		}
+		list, err := s.lister.Kubeconfigs(s.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, kubeconfig := range list {
+			if kubeconfig.Spec.KubeconfigFrom != nil {
+				logger.Debugw("Skipping federated kubeconfig using kubeconfigFrom", "source", s.name, "name", kubeconfig.Name)
+				continue
+			}
+			kubeconfigs = append(kubeconfigs, s.mark(kubeconfig, status.Stale))
+		}
+	}
+	return kubeconfigs, nil
+}
+
+func (c *federatedCatalog) Get(id string) (*v1alpha1.Kubeconfig, error) {
+	name, kubeconfigName, _ := strings.Cut(id, ":")
+	for _, s := range c.sources {
+		if s.name != name {
+			continue
+		}
+		kubeconfig, err := s.lister.Kubeconfigs(s.namespace).Get(kubeconfigName)
+		if err != nil {
+			return nil, err
+		}
+		if kubeconfig.Spec.KubeconfigFrom != nil {
+			break
+		}
+		return s.mark(kubeconfig, s.Status().Stale), nil
+	}
+	return nil, apierrors.NewNotFound(v1alpha1.Resource("kubeconfigs"), id)
+}
+
+func (c *federatedCatalog) Status() CatalogStatus {
+	status := CatalogStatus{Provider: catalogProviderFederated}
+	for _, s := range c.sources {
+		status.Sources = append(status.Sources, s.Status())
+	}
+	return status
+}
+
+// Returns the source cluster of a Kubeconfig of the federated catalog, empty for other catalogs
+func kubeconfigSource(kubeconfig *v1alpha1.Kubeconfig) string {
+	if _, ok := kubecfg.catalog.(*federatedCatalog); !ok {
+		return ""
+	}
+	return kubeconfig.Labels[catalogSourceLabel]
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/fake"
+	listers "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Returns a source serving the Kubeconfigs from its cache, without watching a cluster
+func testCatalogSource(t *testing.T, name string, synced bool, lastError string, kubeconfigs ...*v1alpha1.Kubeconfig) *catalogSource {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, kubeconfig := range kubeconfigs {
+		if err := indexer.Add(kubeconfig); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &catalogSource{
+		name:      name,
+		namespace: "kubebrowser",
+		client:    fake.NewSimpleClientset(),
+		lister:    listers.NewKubeconfigLister(indexer),
+		synced:    func() bool { return synced },
+		status:    CatalogSourceStatus{Name: name, LastError: lastError},
+	}
+}
+
+func testSourceKubeconfig(name string, kubeconfigFrom bool) *v1alpha1.Kubeconfig {
+	kubeconfig := &v1alpha1.Kubeconfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: name},
+		Spec:       testKubeconfigSpec(name),
+	}
+	if kubeconfigFrom {
+		kubeconfig.Spec.KubeconfigFrom = &v1alpha1.KubeconfigSource{SecretRef: &v1alpha1.KeyReference{Name: name}}
+	}
+	return kubeconfig
+}
+
+func TestFederatedCatalog(t *testing.T) {
+	catalog := &federatedCatalog{sources: []*catalogSource{
+		testCatalogSource(t, "eu", true, "", testSourceKubeconfig("prod", false), testSourceKubeconfig("sourced", true)),
+		testCatalogSource(t, "us", true, "connection refused", testSourceKubeconfig("prod", false)),
+		testCatalogSource(t, "asia", false, "", testSourceKubeconfig("prod", false)),
+	}}
+	previousCatalog := kubecfg.catalog
+	kubecfg.catalog = catalog
+	t.Cleanup(func() { kubecfg.catalog = previousCatalog })
+
+	kubeconfigs, err := catalog.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, kubeconfig := range kubeconfigs {
+		ids = append(ids, kubeconfigID(kubeconfig))
+		if kubeconfigSource(kubeconfig) == "" {
+			t.Errorf("%s has no source", kubeconfigID(kubeconfig))
+		}
+		if stale := kubeconfig.Annotations[staleAnnotation] == "true"; stale != (kubeconfigSource(kubeconfig) == "us") {
+			t.Errorf("%s stale = %v", kubeconfigID(kubeconfig), stale)
+		}
+	}
+	if want := []string{"eu:prod", "us:prod"}; !slices.Equal(ids, want) {
+		t.Errorf("List() = %q, want %q", ids, want)
+	}
+
+	tests := []struct {
+		id        string
+		wantFound bool
+		wantStale bool
+	}{
+		{id: "eu:prod", wantFound: true},
+		{id: "us:prod", wantFound: true, wantStale: true},
+		{id: "eu:sourced"},
+		{id: "eu:unknown"},
+		{id: "other:prod"},
+		{id: "prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			kubeconfig, err := catalog.Get(tt.id)
+			if !tt.wantFound {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Get() error = %v, want NotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if kubeconfigID(kubeconfig) != tt.id {
+				t.Errorf("Get() ID = %q, want %q", kubeconfigID(kubeconfig), tt.id)
+			}
+			if stale := kubeconfig.Annotations[staleAnnotation] == "true"; stale != tt.wantStale {
+				t.Errorf("Get() stale = %v, want %v", stale, tt.wantStale)
+			}
+		})
+	}
+
+	status := catalog.Status()
+	if status.Provider != catalogProviderFederated || len(status.Sources) != 3 {
+		t.Fatalf("Status() = %+v, want the 3 sources", status)
+	}
+	for i, want := range []CatalogSourceStatus{
+		{Name: "eu", Synced: true},
+		{Name: "us", Synced: true, Stale: true, LastError: "connection refused"},
+		{Name: "asia"},
+	} {
+		if status.Sources[i] != want {
+			t.Errorf("Status() source %d = %+v, want %+v", i, status.Sources[i], want)
+		}
+	}
+}
+
+func TestCatalogSourceMarkDoesNotModifyCache(t *testing.T) {
+	kubeconfig := testSourceKubeconfig("prod", false)
+	source := testCatalogSource(t, "eu", true, "", kubeconfig)
+
+	marked := source.mark(kubeconfig, true)
+	if marked.Labels[catalogSourceLabel] != "eu" || marked.Annotations[staleAnnotation] != "true" {
+		t.Errorf("mark() metadata = %+v, want the source label and stale annotation", marked.ObjectMeta)
+	}
+	if kubeconfig.Labels != nil || kubeconfig.Annotations != nil {
+		t.Errorf("mark() modified the cached Kubeconfig: %+v", kubeconfig.ObjectMeta)
+	}
+}
+
+func TestCatalogSourceCheck(t *testing.T) {
+	source := testCatalogSource(t, "eu", true, "")
+	client := source.client.(*fake.Clientset)
+	var unreachable bool
+	client.PrependReactor("list", "kubeconfigs", func(clienttesting.Action) (bool, runtime.Object, error) {
+		if unreachable {
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+
+	source.check(context.Background())
+	if status := source.Status(); status.Stale || status.LastSyncTime == nil {
+		t.Fatalf("Status() = %+v, want a fresh source", status)
+	}
+
+	unreachable = true
+	source.check(context.Background())
+	if status := source.Status(); !status.Stale || status.LastError != "connection refused" {
+		t.Fatalf("Status() = %+v, want a stale source", status)
+	}
+
+	unreachable = false
+	source.check(context.Background())
+	if status := source.Status(); status.Stale || status.LastError != "" {
+		t.Fatalf("Status() = %+v, want a recovered source", status)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	unreachable = true
+	source.check(ctx)
+	if status := source.Status(); status.Stale {
+		t.Errorf("Status() = %+v, a cancelled check must not mark the source stale", status)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Catalog of the Kubeconfig manifests of a directory of a git repository, synced periodically
+// or when triggered. Repositories are fetched with the git command, which must be installed.
+type gitCatalog struct {
+	snapshotCatalog
+	url  string
+	ref  string
+	path string
+	// Local clone of the repository
+	dir     string
+	trigger chan struct{}
+
+	mu     sync.Mutex
+	status CatalogStatus
+}
+
+// Clones the repository and syncs it every interval until the context is cancelled
+func newGitCatalog(ctx context.Context, url, ref, path string, interval time.Duration) (*gitCatalog, error) {
+	if url == "" {
+		return nil, fmt.Errorf("%s is required with the git catalog provider", catalogGitURLKey)
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	dir, err := os.MkdirTemp("", "kubebrowser-catalog-")
+	if err != nil {
+		return nil, err
+	}
+	c := &gitCatalog{
+		url:     url,
+		ref:     ref,
+		path:    path,
+		dir:     dir,
+		trigger: make(chan struct{}, 1),
+		status:  CatalogStatus{Provider: catalogProviderGit},
+	}
+	if err := c.git(ctx, "init", "--quiet"); err != nil {
+		return nil, err
+	}
+	if err := c.git(ctx, "remote", "add", "origin", url); err != nil {
+		return nil, err
+	}
+	if err := c.sync(ctx); err != nil {
+		return nil, err
+	}
+
+	go c.run(ctx, interval)
+	return c, nil
+}
+
+// Requests a sync, coalesced with the pending one if any
+func (c *gitCatalog) Trigger() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *gitCatalog) Status() CatalogStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+func (c *gitCatalog) run(ctx context.Context, interval time.Duration) {
+	defer os.RemoveAll(c.dir)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.trigger:
+		}
+		if err := c.sync(ctx); err != nil {
+			logger.Errorf("Error syncing kubeconfig repository, keeping the previous revision: %s", err)
+		}
+	}
+}
+
+// Fetches the ref, then loads and validates the manifests of a new revision before replacing the
+// catalog. The last good revision is kept on error.
+func (c *gitCatalog) sync(ctx context.Context) error {
+	revision, err := c.fetch(ctx)
+	if err == nil && revision != c.Status().Revision {
+		err = c.load(ctx, revision)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.status.LastSyncTime = &now
+	if err != nil {
+		c.status.LastError = err.Error()
+		return err
+	}
+	c.status.LastError = ""
+	c.status.Revision = revision
+	return nil
+}
+
+// Fetches the ref and returns the SHA of its commit
+func (c *gitCatalog) fetch(ctx context.Context) (string, error) {
+	if err := c.git(ctx, "fetch", "--quiet", "--depth", "1", "--force", "origin", c.ref); err != nil {
+		return "", err
+	}
+	out, err := c.gitOutput(ctx, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (c *gitCatalog) load(ctx context.Context, revision string) error {
+	if err := c.git(ctx, "checkout", "--quiet", "--force", "--detach", revision); err != nil {
+		return err
+	}
+	kubeconfigs, err := loadKubeconfigDirectory(filepath.Join(c.dir, c.path))
+	if err != nil {
+		return err
+	}
+	if err := validateCatalog(kubeconfigs); err != nil {
+		return err
+	}
+
+	c.kubeconfigs.Store(&kubeconfigs)
+	catalogChanges.publish()
+	logger.Infow("Loaded kubeconfig repository", "url", c.url, "revision", revision, "kubeconfigs", len(kubeconfigs))
+	return nil
+}
+
+func (c *gitCatalog) git(ctx context.Context, args ...string) error {
+	_, err := c.gitOutput(ctx, args...)
+	return err
+}
+
+// Runs a git command in the clone, without prompting for credentials
+func (c *gitCatalog) gitOutput(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = c.dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Checks that every Kubeconfig of a catalog can be rendered and has a unique display name
+func validateCatalog(kubeconfigs map[string]*v1alpha1.Kubeconfig) error {
+	others := make([]*v1alpha1.Kubeconfig, 0, len(kubeconfigs))
+	for _, kubeconfig := range kubeconfigs {
+		others = append(others, kubeconfig)
+	}
+
+	for name, kubeconfig := range kubeconfigs {
+		var allErrs field.ErrorList
+		allErrs = append(allErrs, validateKubeconfigSpec(&kubeconfig.Spec)...)
+		allErrs = append(allErrs, validateUniqueDisplayName(kubeconfig, others)...)
+		if len(allErrs) > 0 {
+			return fmt.Errorf("kubeconfig %s: %w", name, allErrs.ToAggregate())
+		}
+	}
+	return nil
+}
+
+// Triggers a sync of the git catalog, for the push webhooks of git hosting services. Callers
+// authenticate with the shared token as a bearer token.
+func handleSyncCatalog(c *gin.Context) {
+	token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	expected := viper.GetString(catalogGitWebhookTokenKey)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		c.String(http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	catalog, ok := kubecfg.catalog.(*gitCatalog)
+	if !ok {
+		c.String(http.StatusNotFound, "Catalog is not synced from git")
+		return
+	}
+	catalog.Trigger()
+	c.Status(http.StatusAccepted)
+}
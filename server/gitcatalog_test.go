@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Local repository committing manifests for the git catalog to sync
+type testGitRepository struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestGitRepository(t *testing.T) *testGitRepository {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	r := &testGitRepository{t: t, dir: t.TempDir()}
+	r.git("init", "--quiet")
+	return r
+}
+
+func (r *testGitRepository) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Kubebrowser", "-c", "user.email=kubebrowser@example.com"}, args...)...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %s: %s: %s", args[0], err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Commits the file in the catalog directory and returns the SHA of the commit
+func (r *testGitRepository) commit(name, content string) string {
+	r.t.Helper()
+	if err := os.MkdirAll(filepath.Join(r.dir, "catalog"), 0o700); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, "catalog", name), []byte(content), 0o600); err != nil {
+		r.t.Fatal(err)
+	}
+	r.git("add", "--all")
+	r.git("commit", "--quiet", "--message", "Update "+name)
+	return r.git("rev-parse", "HEAD")
+}
+
+func TestGitCatalogKeepsLastGoodRevision(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	repository := newTestGitRepository(t)
+	good := repository.commit("staging.yaml", testV1beta1Manifest)
+
+	catalog, err := newGitCatalog(ctx, "file://"+repository.dir, "", "catalog", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := catalog.Status(); status.Revision != good || status.LastError != "" {
+		t.Fatalf("Status() = %+v, want revision %s", status, good)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "invalid manifest", file: "broken.yaml", content: "apiVersion: ["},
+		{
+			name:    "invalid kubeconfig",
+			file:    "broken.yaml",
+			content: "apiVersion: kubebrowser.io/v1alpha1\nkind: Kubeconfig\nmetadata:\n  name: insecure\nspec:\n  name: Insecure\n  kubeconfig:\n    clusters:\n      - name: insecure\n        cluster:\n          server: http://insecure.example.com\n",
+		},
+		{name: "duplicate display name", file: "broken.yaml", content: strings.ReplaceAll(testV1beta1Manifest, "name: staging\nspec", "name: copy\nspec")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository.commit(tt.file, tt.content)
+
+			if err := catalog.sync(ctx); err == nil {
+				t.Fatal("sync() succeeded with a broken revision")
+			}
+			if status := catalog.Status(); status.Revision != good || status.LastError == "" || status.LastSyncTime == nil {
+				t.Errorf("Status() = %+v, want the error and revision %s", status, good)
+			}
+			if kubeconfigs, _ := catalog.List(); len(kubeconfigs) != 1 || kubeconfigs[0].Name != "staging" {
+				t.Errorf("List() = %v, want the Kubeconfig of the last good revision", kubeconfigs)
+			}
+		})
+	}
+
+	fixed := repository.commit("broken.yaml", "# Fixed\n")
+	if err := catalog.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if status := catalog.Status(); status.Revision != fixed || status.LastError != "" {
+		t.Errorf("Status() = %+v, want revision %s", status, fixed)
+	}
+	if _, err := catalog.Get("staging"); err != nil {
+		t.Errorf("Get() error = %v, want the Kubeconfig of the new revision", err)
+	}
+}
+
+func TestValidateCatalog(t *testing.T) {
+	insecure := testKubeconfigSpec("Insecure")
+	insecure.Kubeconfig.Clusters[0].Cluster.Server = "http://insecure.example.com"
+
+	tests := []struct {
+		name        string
+		kubeconfigs map[string]v1alpha1.KubeconfigSpec
+		wantErr     bool
+	}{
+		{name: "empty catalog"},
+		{
+			name:        "valid catalog",
+			kubeconfigs: map[string]v1alpha1.KubeconfigSpec{"prod": testKubeconfigSpec("Production"), "staging": testKubeconfigSpec("Staging")},
+		},
+		{
+			name:        "invalid kubeconfig",
+			kubeconfigs: map[string]v1alpha1.KubeconfigSpec{"prod": testKubeconfigSpec("Production"), "insecure": insecure},
+			wantErr:     true,
+		},
+		{
+			name:        "duplicate display name",
+			kubeconfigs: map[string]v1alpha1.KubeconfigSpec{"prod": testKubeconfigSpec("Production"), "production": testKubeconfigSpec("Production")},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfigs := make(map[string]*v1alpha1.Kubeconfig, len(tt.kubeconfigs))
+			for name, spec := range tt.kubeconfigs {
+				kubeconfigs[name] = &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+			}
+
+			if err := validateCatalog(kubeconfigs); (err != nil) != tt.wantErr {
+				t.Errorf("validateCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleSyncCatalog(t *testing.T) {
+	viper.Set(catalogGitWebhookTokenKey, "secret")
+	previousCatalog := kubecfg.catalog
+	t.Cleanup(func() {
+		viper.Set(catalogGitWebhookTokenKey, "")
+		kubecfg.catalog = previousCatalog
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		catalog       Catalog
+		wantCode      int
+	}{
+		{name: "no token", catalog: &gitCatalog{}, wantCode: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer other", catalog: &gitCatalog{}, wantCode: http.StatusUnauthorized},
+		{name: "token without scheme", authorization: "secret", catalog: &gitCatalog{}, wantCode: http.StatusAccepted},
+		{name: "valid token", authorization: "Bearer secret", catalog: &gitCatalog{}, wantCode: http.StatusAccepted},
+		{name: "other provider", authorization: "Bearer secret", catalog: kubernetesCatalog{}, wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog, isGit := tt.catalog.(*gitCatalog)
+			if isGit {
+				catalog.trigger = make(chan struct{}, 1)
+			}
+			kubecfg.catalog = tt.catalog
+
+			router := gin.New()
+			router.POST("/api/catalog/sync", handleSyncCatalog)
+			request := httptest.NewRequest(http.MethodPost, "/api/catalog/sync", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", recorder.Code, tt.wantCode)
+			}
+			if isGit {
+				if triggered := len(catalog.trigger) == 1; triggered != (tt.wantCode == http.StatusAccepted) {
+					t.Errorf("triggered = %v, want a sync only with a valid token", triggered)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"slices"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Group of the user with its display name, if any GroupAlias matches its ID
+type Group struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Returns the display names of group IDs, as declared by GroupAliases. Aliases sharing their ID or
+// display name with another alias, or whose display name is the ID of another alias, are ambiguous
+// and ignored.
+func groupAliases() (map[string]string, error) {
+	if !kubecfg.HasCluster() {
+		return nil, nil
+	}
+	aliases, err := kubecfg.aliasLister.GroupAliases(viper.GetString(podNamespaceKey)).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return groupAliasNames(aliases), nil
+}
+
+func groupAliasNames(aliases []*v1alpha1.GroupAlias) map[string]string {
+	uses := make(map[string]int, 2*len(aliases))
+	for _, alias := range aliases {
+		uses[alias.Spec.ID]++
+		if alias.Spec.DisplayName != alias.Spec.ID {
+			uses[alias.Spec.DisplayName]++
+		}
+	}
+
+	names := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		if uses[alias.Spec.ID] > 1 || uses[alias.Spec.DisplayName] > 1 {
+			logger.Warnw("Ignoring ambiguous group alias", "name", alias.Name, "id", alias.Spec.ID, "displayName", alias.Spec.DisplayName)
+			continue
+		}
+		names[alias.Spec.ID] = alias.Spec.DisplayName
+	}
+	return names
+}
+
+// Lets whitelists and groups of the configuration reference the groups of the claims by the
+// display name of their alias. The groups of the claims stay the IDs of the identity provider.
+func resolveGroupAliases(claims EmailAndGroups, aliases map[string]string) EmailAndGroups {
+	claims.aliasIDs = make(map[string]string, len(aliases))
+	for id, name := range aliases {
+		claims.aliasIDs[name] = id
+	}
+	return claims
+}
+
+// Returns true if the user is a member of the group. A group is referenced by the display name of
+// its alias if there is one with this name, and by its ID otherwise.
+func (claims EmailAndGroups) memberOf(group string) bool {
+	if id, ok := claims.aliasIDs[group]; ok {
+		group = id
+	}
+	return slices.Contains(claims.Groups, group)
+}
+
+// Extracts the email and groups of the user from session, with group aliases resolved
+func userClaims(c *gin.Context) (EmailAndGroups, error) {
+	var claims EmailAndGroups
+	if err := sessionClaims(c, &claims); err != nil {
+		return claims, err
+	}
+
+	aliases, err := groupAliases()
+	if err != nil {
+		return claims, err
+	}
+	return resolveGroupAliases(claims, aliases), nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"maps"
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func groupAlias(name, id, displayName string) *v1alpha1.GroupAlias {
+	return &v1alpha1.GroupAlias{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.GroupAliasSpec{ID: id, DisplayName: displayName},
+	}
+}
+
+func TestGroupAliasNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases []*v1alpha1.GroupAlias
+		want    map[string]string
+	}{
+		{
+			name:    "no aliases",
+			aliases: nil,
+			want:    map[string]string{},
+		},
+		{
+			name: "distinct aliases",
+			aliases: []*v1alpha1.GroupAlias{
+				groupAlias("platform", "5091ba02", "platform-team"),
+				groupAlias("dev", "77c1e0a4", "developers"),
+			},
+			want: map[string]string{"5091ba02": "platform-team", "77c1e0a4": "developers"},
+		},
+		{
+			name: "display name equal to its own ID",
+			aliases: []*v1alpha1.GroupAlias{
+				groupAlias("ops", "ops", "ops"),
+			},
+			want: map[string]string{"ops": "ops"},
+		},
+		{
+			name: "duplicate ID",
+			aliases: []*v1alpha1.GroupAlias{
+				groupAlias("platform", "5091ba02", "platform-team"),
+				groupAlias("platform-bis", "5091ba02", "platform"),
+				groupAlias("dev", "77c1e0a4", "developers"),
+			},
+			want: map[string]string{"77c1e0a4": "developers"},
+		},
+		{
+			name: "duplicate display name",
+			aliases: []*v1alpha1.GroupAlias{
+				groupAlias("platform", "5091ba02", "platform-team"),
+				groupAlias("platform-bis", "9e3d7a51", "platform-team"),
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "display name equal to another ID",
+			aliases: []*v1alpha1.GroupAlias{
+				groupAlias("platform", "5091ba02", "platform-team"),
+				groupAlias("spoof", "77c1e0a4", "5091ba02"),
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupAliasNames(tt.aliases); !maps.Equal(got, tt.want) {
+				t.Errorf("groupAliasNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberOf(t *testing.T) {
+	aliases := map[string]string{"5091ba02": "platform-team"}
+
+	tests := []struct {
+		name   string
+		groups []string
+		group  string
+		want   bool
+	}{
+		{name: "by ID", groups: []string{"5091ba02"}, group: "5091ba02", want: true},
+		{name: "by display name", groups: []string{"5091ba02"}, group: "platform-team", want: true},
+		{name: "not a member", groups: []string{"77c1e0a4"}, group: "platform-team", want: false},
+		{name: "raw group without alias", groups: []string{"developers"}, group: "developers", want: true},
+		{name: "raw group named like a display name", groups: []string{"platform-team"}, group: "platform-team", want: false},
+		{name: "no groups", groups: nil, group: "platform-team", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := resolveGroupAliases(EmailAndGroups{Email: "jane@example.com", Groups: tt.groups}, aliases)
+			if got := claims.memberOf(tt.group); got != tt.want {
+				t.Errorf("memberOf(%q) = %v, want %v", tt.group, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchWhitelistAliases(t *testing.T) {
+	claims := resolveGroupAliases(EmailAndGroups{Email: "jane@example.com", Groups: []string{"5091ba02"}}, map[string]string{"5091ba02": "platform-team"})
+
+	tests := []struct {
+		name      string
+		whitelist *v1alpha1.Whitelist
+		want      bool
+	}{
+		{name: "nil whitelist", whitelist: nil, want: false},
+		{name: "user", whitelist: &v1alpha1.Whitelist{Users: []string{"jane@example.com"}}, want: true},
+		{name: "group ID", whitelist: &v1alpha1.Whitelist{Groups: []string{"5091ba02"}}, want: true},
+		{name: "group display name", whitelist: &v1alpha1.Whitelist{Groups: []string{"developers", "platform-team"}}, want: true},
+		{name: "other group", whitelist: &v1alpha1.Whitelist{Groups: []string{"developers"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchWhitelist(tt.whitelist, claims); got != tt.want {
+				t.Errorf("matchWhitelist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// Label of a namespace naming the team owning its Kubeconfigs
+	teamLabel = "kubebrowser.io/team"
+
+	// Annotations configuring the Kubeconfigs imported from other tools
+	displayNameAnnotation    = "kubebrowser.io/display-name"
+	allowedUsersAnnotation   = "kubebrowser.io/allowed-users"
+	allowedGroupsAnnotation  = "kubebrowser.io/allowed-groups"
+	approverUsersAnnotation  = "kubebrowser.io/approver-users"
+	approverGroupsAnnotation = "kubebrowser.io/approver-groups"
+	descriptionAnnotation    = "kubebrowser.io/description"
+	environmentAnnotation    = "kubebrowser.io/environment"
+	regionAnnotation         = "kubebrowser.io/region"
+	providerAnnotation       = "kubebrowser.io/provider"
+	ownerAnnotation          = "kubebrowser.io/owner"
+	contactAnnotation        = "kubebrowser.io/contact"
+	tagsAnnotation           = "kubebrowser.io/tags"
+	// Publishes an imported cluster to every user when set to "true"
+	publicAnnotation = "kubebrowser.io/public"
+)
+
+// Returns whether Kubeconfigs are watched in other namespaces than the pod namespace
+func watchesMultipleNamespaces() bool {
+	return viper.GetString(watchNamespacesKey) != "" || viper.GetString(watchNamespaceSelectorKey) != ""
+}
+
+// Returns whether the Kubeconfigs of the namespace are served
+func watchedNamespace(namespace string) bool {
+	if namespace == viper.GetString(podNamespaceKey) {
+		return true
+	}
+	if slices.Contains(splitList(viper.GetString(watchNamespacesKey)), namespace) {
+		return true
+	}
+	if kubecfg.namespaceSelector == nil {
+		return false
+	}
+	ns, err := kubecfg.namespaceLister.Get(namespace)
+	if err != nil {
+		return false
+	}
+	return kubecfg.namespaceSelector.Matches(labels.Set(ns.Labels))
+}
+
+// Returns the ID of a Kubeconfig: its name in the pod namespace and for ClusterKubeconfigs,
+// namespace/name otherwise, prefixed for Cluster API Clusters, Argo CD clusters and the
+// Kubeconfigs of a federated source cluster
+func kubeconfigID(kubeconfig *v1alpha1.Kubeconfig) string {
+	if source := kubeconfigSource(kubeconfig); source != "" {
+		return source + ":" + kubeconfig.Name
+	}
+	if isCAPIKubeconfig(kubeconfig) {
+		return capiIDPrefix + kubeconfig.Namespace + "/" + kubeconfig.Name
+	}
+	if isArgoCDKubeconfig(kubeconfig) {
+		return argoCDIDPrefix + kubeconfig.Name
+	}
+	if kubeconfig.Namespace == "" || kubeconfig.Namespace == viper.GetString(podNamespaceKey) {
+		return kubeconfig.Name
+	}
+	return kubeconfig.Namespace + "/" + kubeconfig.Name
+}
+
+// Returns the team owning a Kubeconfig, as labeled on its namespace
+func kubeconfigTeam(kubeconfig *v1alpha1.Kubeconfig) string {
+	if kubecfg.namespaceLister == nil || kubeconfig.Namespace == "" || kubeconfigSource(kubeconfig) != "" {
+		return ""
+	}
+	ns, err := kubecfg.namespaceLister.Get(kubeconfig.Namespace)
+	if err != nil {
+		return ""
+	}
+	return ns.Labels[teamLabel]
+}
+
+// Catalog provides the Kubeconfigs served to users
+type Catalog interface {
+	// Lists all the Kubeconfigs, before any filtering on the user
+	List() ([]*v1alpha1.Kubeconfig, error)
+	// Returns the Kubeconfig with this ID, or a NotFound error
+	Get(id string) (*v1alpha1.Kubeconfig, error)
+}
+
+// CatalogStatus reports the state of a catalog loaded from external sources
+type CatalogStatus struct {
+	Provider string `json:"provider"`
+	// Commit served by the git provider
+	Revision     string     `json:"revision,omitempty"`
+	LastSyncTime *time.Time `json:"lastSyncTime,omitempty"`
+	// Error of the last sync, the previous revision is still served
+	LastError string `json:"lastError,omitempty"`
+	// Source clusters of the federated provider
+	Sources []CatalogSourceStatus `json:"sources,omitempty"`
+}
+
+// CatalogSourceStatus reports the sync health of a source cluster of the federated provider
+type CatalogSourceStatus struct {
+	Name   string `json:"name"`
+	Synced bool   `json:"synced"`
+	// Set when the source cannot be reached, its last known Kubeconfigs are served as stale
+	Stale        bool       `json:"stale"`
+	LastSyncTime *time.Time `json:"lastSyncTime,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+}
+
+// Lists the Kubeconfigs of the catalog
+func listKubeconfigs() ([]*v1alpha1.Kubeconfig, error) {
+	return kubecfg.catalog.List()
+}
+
+// Returns the Kubeconfig of the catalog with this ID
+func getKubeconfig(id string) (*v1alpha1.Kubeconfig, error) {
+	return kubecfg.catalog.Get(id)
+}
+
+// Catalog of the Kubeconfig resources of the cluster and of the clusters imported from other tools
+type kubernetesCatalog struct{}
+
+// Lists the Kubeconfigs of the watched namespaces along with the ClusterKubeconfigs, resolved
+// from their source, and the clusters imported from Cluster API and Argo CD. A Kubeconfig of the
+// pod namespace hides the ClusterKubeconfig of the same name.
+func (kubernetesCatalog) List() ([]*v1alpha1.Kubeconfig, error) {
+	all, err := kubecfg.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	clusterKubeconfigs, err := kubecfg.clusterLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigs := make([]*v1alpha1.Kubeconfig, 0, len(all)+len(clusterKubeconfigs))
+	ids := make(map[string]bool, len(all))
+	for _, kubeconfig := range all {
+		if !watchedNamespace(kubeconfig.Namespace) {
+			continue
+		}
+		ids[kubeconfigID(kubeconfig)] = true
+		if resolved, err := resolveKubeconfig(kubeconfig); err != nil {
+			logger.Warnw("Skipping kubeconfig with unavailable source", "id", kubeconfigID(kubeconfig), "error", err)
+		} else {
+			kubeconfigs = append(kubeconfigs, resolved)
+		}
+	}
+	for _, clusterKubeconfig := range clusterKubeconfigs {
+		if ids[clusterKubeconfig.Name] {
+			logger.Warnw("ClusterKubeconfig hidden by a Kubeconfig of the same name", "name", clusterKubeconfig.Name)
+			continue
+		}
+		if resolved, err := resolveKubeconfig(fromClusterKubeconfig(clusterKubeconfig)); err != nil {
+			logger.Warnw("Skipping cluster kubeconfig with unavailable source", "name", clusterKubeconfig.Name, "error", err)
+		} else {
+			kubeconfigs = append(kubeconfigs, resolved)
+		}
+	}
+
+	capiKubeconfigs, err := listCAPIKubeconfigs()
+	if err != nil {
+		return nil, err
+	}
+	argoCDKubeconfigs, err := listArgoCDKubeconfigs()
+	if err != nil {
+		return nil, err
+	}
+	kubeconfigs = append(kubeconfigs, capiKubeconfigs...)
+	return append(kubeconfigs, argoCDKubeconfigs...), nil
+}
+
+// Returns the Kubeconfig with this ID, resolved from its source. An ID without namespace is looked
+// up in the pod namespace, then in the ClusterKubeconfigs.
+func (kubernetesCatalog) Get(id string) (*v1alpha1.Kubeconfig, error) {
+	if key, ok := strings.CutPrefix(id, capiIDPrefix); ok {
+		return getCAPIKubeconfig(key)
+	}
+	if name, ok := strings.CutPrefix(id, argoCDIDPrefix); ok {
+		return getArgoCDKubeconfig(name)
+	}
+	if namespace, name, ok := strings.Cut(id, "/"); ok {
+		if !watchedNamespace(namespace) {
+			return nil, apierrors.NewNotFound(v1alpha1.Resource("kubeconfigs"), id)
+		}
+		kubeconfig, err := kubecfg.lister.Kubeconfigs(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return resolveKubeconfig(kubeconfig)
+	}
+
+	kubeconfig, err := kubecfg.lister.Kubeconfigs(viper.GetString(podNamespaceKey)).Get(id)
+	if err == nil {
+		return resolveKubeconfig(kubeconfig)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	clusterKubeconfig, err := kubecfg.clusterLister.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return resolveKubeconfig(fromClusterKubeconfig(clusterKubeconfig))
+}
+
+// Returns the spec of a Kubeconfig imported from another tool, configured by the annotations of
+// the imported object
+func annotatedKubeconfigSpec(annotations map[string]string, name string, data *v1alpha1.KubeconfigData) v1alpha1.KubeconfigSpec {
+	spec := v1alpha1.KubeconfigSpec{
+		Name:        name,
+		Description: annotations[descriptionAnnotation],
+		Environment: annotations[environmentAnnotation],
+		Region:      annotations[regionAnnotation],
+		Provider:    annotations[providerAnnotation],
+		Owner:       annotations[ownerAnnotation],
+		Contact:     annotations[contactAnnotation],
+		Tags:        splitList(annotations[tagsAnnotation]),
+		Kubeconfig:  *data,
+		Whitelist:   importedWhitelist(annotations),
+		Approvers:   annotationWhitelist(annotations, approverUsersAnnotation, approverGroupsAnnotation),
+	}
+	if displayName := annotations[displayNameAnnotation]; displayName != "" {
+		spec.Name = displayName
+	}
+	return spec
+}
+
+// Returns the whitelist of an imported cluster. Imported clusters are hidden unless their
+// annotations allow users or groups, or publish them to everyone.
+func importedWhitelist(annotations map[string]string) *v1alpha1.Whitelist {
+	if annotations[publicAnnotation] == "true" {
+		return nil
+	}
+	if whitelist := annotationWhitelist(annotations, allowedUsersAnnotation, allowedGroupsAnnotation); whitelist != nil {
+		return whitelist
+	}
+	return &v1alpha1.Whitelist{}
+}
+
+// Returns the metadata of an imported object to keep in the cache, without its managed fields and
+// its last applied configuration, which holds the whole object, credentials included
+func strippedObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	meta.ManagedFields = nil
+	if _, ok := meta.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		meta.Annotations = maps.Clone(meta.Annotations)
+		delete(meta.Annotations, corev1.LastAppliedConfigAnnotation)
+	}
+	return meta
+}
+
+// Returns the kubebrowser.io annotations of an imported object, the only ones the catalog uses
+func kubebrowserAnnotations(annotations map[string]string) map[string]string {
+	kept := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if strings.HasPrefix(key, "kubebrowser.io/") {
+			kept[key] = value
+		}
+	}
+	return kept
+}
+
+// Returns a whitelist from comma separated annotations, nil if both are unset
+func annotationWhitelist(annotations map[string]string, usersKey, groupsKey string) *v1alpha1.Whitelist {
+	users := splitList(annotations[usersKey])
+	groups := splitList(annotations[groupsKey])
+	if len(users) == 0 && len(groups) == 0 {
+		return nil
+	}
+	return &v1alpha1.Whitelist{Users: users, Groups: groups}
+}
+
+// Returns the status of catalogs loaded from an external source
+func handleGetCatalogStatus(c *gin.Context) {
+	catalog, ok := kubecfg.catalog.(interface{ Status() CatalogStatus })
+	if !ok {
+		c.JSON(http.StatusOK, CatalogStatus{Provider: viper.GetString(catalogProviderKey)})
+		return
+	}
+	c.JSON(http.StatusOK, catalog.Status())
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestAnnotatedKubeconfigSpec(t *testing.T) {
+	data := &v1alpha1.KubeconfigData{APIVersion: "v1", Kind: "Config"}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantName      string
+		wantWhitelist *v1alpha1.Whitelist
+		wantApprovers *v1alpha1.Whitelist
+	}{
+		{
+			name:          "no annotations",
+			annotations:   nil,
+			wantName:      "prod",
+			wantWhitelist: &v1alpha1.Whitelist{},
+		},
+		{
+			name:          "public",
+			annotations:   map[string]string{publicAnnotation: "true"},
+			wantName:      "prod",
+			wantWhitelist: nil,
+		},
+		{
+			name:          "public set to another value",
+			annotations:   map[string]string{publicAnnotation: "yes"},
+			wantName:      "prod",
+			wantWhitelist: &v1alpha1.Whitelist{},
+		},
+		{
+			name: "allowed users and groups",
+			annotations: map[string]string{
+				displayNameAnnotation:    "Production",
+				allowedUsersAnnotation:   "alice@example.com",
+				allowedGroupsAnnotation:  "platform-team, developers",
+				approverGroupsAnnotation: "platform-team",
+			},
+			wantName:      "Production",
+			wantWhitelist: &v1alpha1.Whitelist{Users: []string{"alice@example.com"}, Groups: []string{"platform-team", "developers"}},
+			wantApprovers: &v1alpha1.Whitelist{Groups: []string{"platform-team"}},
+		},
+		{
+			name:          "empty allowed groups",
+			annotations:   map[string]string{allowedGroupsAnnotation: " , "},
+			wantName:      "prod",
+			wantWhitelist: &v1alpha1.Whitelist{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := annotatedKubeconfigSpec(tt.annotations, "prod", data)
+			if spec.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", spec.Name, tt.wantName)
+			}
+			if !equality.Semantic.DeepEqual(spec.Whitelist, tt.wantWhitelist) {
+				t.Errorf("Whitelist = %+v, want %+v", spec.Whitelist, tt.wantWhitelist)
+			}
+			if !equality.Semantic.DeepEqual(spec.Approvers, tt.wantApprovers) {
+				t.Errorf("Approvers = %+v, want %+v", spec.Approvers, tt.wantApprovers)
+			}
+		})
+	}
+}
+
+func TestImportedKubeconfigHiddenByDefault(t *testing.T) {
+	kubeconfig := &v1alpha1.Kubeconfig{Spec: annotatedKubeconfigSpec(nil, "prod", &v1alpha1.KubeconfigData{})}
+	claims := EmailAndGroups{Email: "jane@example.com", Groups: []string{"developers"}}
+	if filtered := filterKubeConfigs([]*v1alpha1.Kubeconfig{kubeconfig}, claims, nil); len(filtered) != 0 {
+		t.Errorf("filterKubeConfigs() = %d kubeconfigs, want none", len(filtered))
+	}
+}
+
+func TestKubernetesCatalogClusterKubeconfigShadowing(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	viper.Set(watchNamespacesKey, "team-a")
+	t.Cleanup(func() {
+		viper.Set(podNamespaceKey, "")
+		viper.Set(watchNamespacesKey, "")
+	})
+	kubeconfig := func(namespace, name, displayName string) *v1alpha1.Kubeconfig {
+		return &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: testKubeconfigSpec(displayName)}
+	}
+	clusterKubeconfig := func(name, displayName string) *v1alpha1.ClusterKubeconfig {
+		return &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: testKubeconfigSpec(displayName)}
+	}
+	setTestListers(t,
+		[]*v1alpha1.Kubeconfig{
+			kubeconfig("kubebrowser", "prod", "Production"),
+			kubeconfig("team-a", "shared", "Team A shared"),
+		},
+		[]*v1alpha1.ClusterKubeconfig{
+			clusterKubeconfig("prod", "Cluster production"),
+			clusterKubeconfig("shared", "Shared"),
+			clusterKubeconfig("dev", "Development"),
+		},
+	)
+
+	kubeconfigs, err := kubernetesCatalog{}.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, kubeconfig := range kubeconfigs {
+		names = append(names, kubeconfigID(kubeconfig)+"="+kubeconfig.Spec.Name)
+	}
+	slices.Sort(names)
+	want := []string{"dev=Development", "prod=Production", "shared=Shared", "team-a/shared=Team A shared"}
+	if !slices.Equal(names, want) {
+		t.Errorf("List() = %q, want %q", names, want)
+	}
+
+	tests := []struct {
+		id              string
+		wantDisplayName string
+		wantNamespace   string
+	}{
+		// The Kubeconfig of the pod namespace hides the ClusterKubeconfig
+		{id: "prod", wantDisplayName: "Production", wantNamespace: "kubebrowser"},
+		// Kubeconfigs of other namespaces have their own IDs
+		{id: "shared", wantDisplayName: "Shared"},
+		{id: "team-a/shared", wantDisplayName: "Team A shared", wantNamespace: "team-a"},
+		{id: "dev", wantDisplayName: "Development"},
+		{id: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			kubeconfig, err := kubernetesCatalog{}.Get(tt.id)
+			if tt.wantDisplayName == "" {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Get() error = %v, want NotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if kubeconfig.Spec.Name != tt.wantDisplayName || kubeconfig.Namespace != tt.wantNamespace {
+				t.Errorf("Get() = %s %q, want %s %q", kubeconfig.Namespace, kubeconfig.Spec.Name, tt.wantNamespace, tt.wantDisplayName)
+			}
+		})
+	}
+}
+
+// Sets a namespace lister over the namespaces and the selector of the watched namespaces
+func setTestNamespaces(t *testing.T, selector string, namespaces ...*corev1.Namespace) {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, namespace := range namespaces {
+		if err := indexer.Add(namespace); err != nil {
+			t.Fatal(err)
+		}
+	}
+	previousLister, previousSelector := kubecfg.namespaceLister, kubecfg.namespaceSelector
+	kubecfg.namespaceLister, kubecfg.namespaceSelector = corelisters.NewNamespaceLister(indexer), nil
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kubecfg.namespaceSelector = parsed
+	}
+	t.Cleanup(func() { kubecfg.namespaceLister, kubecfg.namespaceSelector = previousLister, previousSelector })
+}
+
+func TestKubeconfigNamespaces(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	viper.Set(watchNamespacesKey, "team-a, team-b")
+	t.Cleanup(func() {
+		viper.Set(podNamespaceKey, "")
+		viper.Set(watchNamespacesKey, "")
+	})
+	setTestNamespaces(t, "kubebrowser.io/catalog=true",
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{teamLabel: "alpha"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-c", Labels: map[string]string{"kubebrowser.io/catalog": "true", teamLabel: "gamma"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-d", Labels: map[string]string{teamLabel: "delta"}}},
+	)
+
+	tests := []struct {
+		namespace   string
+		wantWatched bool
+		wantID      string
+		wantTeam    string
+	}{
+		{namespace: "kubebrowser", wantWatched: true, wantID: "prod"},
+		{namespace: "", wantWatched: false, wantID: "prod"},
+		{namespace: "team-a", wantWatched: true, wantID: "team-a/prod", wantTeam: "alpha"},
+		// Listed but without Namespace object, hence without team
+		{namespace: "team-b", wantWatched: true, wantID: "team-b/prod"},
+		{namespace: "team-c", wantWatched: true, wantID: "team-c/prod", wantTeam: "gamma"},
+		{namespace: "team-d", wantWatched: false, wantID: "team-d/prod", wantTeam: "delta"},
+		{namespace: "unknown", wantWatched: false, wantID: "unknown/prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			kubeconfig := &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Name: "prod"}}
+
+			if watched := watchedNamespace(tt.namespace); watched != tt.wantWatched {
+				t.Errorf("watchedNamespace() = %v, want %v", watched, tt.wantWatched)
+			}
+			if id := kubeconfigID(kubeconfig); id != tt.wantID {
+				t.Errorf("kubeconfigID() = %q, want %q", id, tt.wantID)
+			}
+			if team := kubeconfigTeam(kubeconfig); team != tt.wantTeam {
+				t.Errorf("kubeconfigTeam() = %q, want %q", team, tt.wantTeam)
+			}
+		})
+	}
+}
+
+func TestAnnotatedKubeconfigSpecMetadata(t *testing.T) {
+	spec := annotatedKubeconfigSpec(map[string]string{
+		descriptionAnnotation: "Production cluster",
+		environmentAnnotation: "prod",
+		regionAnnotation:      "eu-west-1",
+		providerAnnotation:    "aws",
+		ownerAnnotation:       "platform",
+		contactAnnotation:     "#platform",
+		tagsAnnotation:        "critical, pci",
+	}, "prod", &v1alpha1.KubeconfigData{})
+
+	want := v1alpha1.KubeconfigSpec{
+		Name:        "prod",
+		Description: "Production cluster",
+		Environment: "prod",
+		Region:      "eu-west-1",
+		Provider:    "aws",
+		Owner:       "platform",
+		Contact:     "#platform",
+		Tags:        []string{"critical", "pci"},
+	}
+	got := spec
+	got.Whitelist, got.Approvers = nil, nil
+	if !equality.Semantic.DeepEqual(got, want) {
+		t.Errorf("annotatedKubeconfigSpec() = %+v, want %+v", got, want)
+	}
+}
+
+func TestKubeconfigViewMetadata(t *testing.T) {
+	kubeconfig := testLifecycleKubeconfig("prod", nil)
+	kubeconfig.Spec.Description = "Production cluster"
+	kubeconfig.Spec.Environment = "prod"
+	kubeconfig.Spec.Region = "eu-west-1"
+	kubeconfig.Spec.Provider = "aws"
+	kubeconfig.Spec.Owner = "platform"
+	kubeconfig.Spec.Contact = "#platform"
+	kubeconfig.Spec.Links = []v1alpha1.Link{{Name: "Runbook", URL: "https://wiki.example.com/prod"}}
+	kubeconfig.Spec.Tags = []string{"critical"}
+
+	views := toKubeConfigViews([]*v1alpha1.Kubeconfig{kubeconfig}, "id-token", "refresh-token")
+	if len(views) != 1 {
+		t.Fatalf("toKubeConfigViews() returned %d views, want 1", len(views))
+	}
+	raw, err := json.Marshal(views[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"description", "environment", "region", "provider", "owner", "contact", "links", "tags"} {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("view has no %s field: %s", field, raw)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"slices"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// Secrets and ConfigMaps need this label set to "true" to be used by a Kubeconfig
+	kubeconfigSourceLabel = "kubebrowser.io/kubeconfig-source"
+	defaultSourceKey      = "kubeconfig"
+)
+
+// Returns the Kubeconfig with the clusters of its source, if any. The Kubeconfig is copied
+// before being modified.
+func resolveKubeconfig(kubeconfig *v1alpha1.Kubeconfig) (*v1alpha1.Kubeconfig, error) {
+	source := kubeconfig.Spec.KubeconfigFrom
+	if source == nil {
+		return kubeconfig, nil
+	}
+
+	namespace := kubeconfig.Namespace
+	if namespace == "" {
+		namespace = viper.GetString(podNamespaceKey)
+	}
+	raw, err := sourceData(namespace, source)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := kubeconfig.DeepCopy()
+	data := &resolved.Spec.Kubeconfig
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("-----BEGIN")) {
+		// A CA bundle applies to every cluster of the Kubeconfig
+		ca := base64.StdEncoding.EncodeToString(raw)
+		for i := range data.Clusters {
+			data.Clusters[i].Cluster.CertificateAuthorityData = ca
+			data.Clusters[i].Cluster.CertificateAuthority = ""
+		}
+	} else {
+		sourced, err := loadWithoutCredentials(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig in source: %w", err)
+		}
+		data.Clusters = sourced.Clusters
+		data.Contexts = sourced.Contexts
+		data.CurrentContext = sourced.CurrentContext
+	}
+
+	if data.APIVersion == "" {
+		data.APIVersion = "v1"
+	}
+	if data.Kind == "" {
+		data.Kind = "Config"
+	}
+	return resolved, nil
+}
+
+// Returns the value of the key referenced by a Kubeconfig source
+func sourceData(namespace string, source *v1alpha1.KubeconfigSource) ([]byte, error) {
+	switch {
+	case source.SecretRef != nil:
+		secret, err := kubecfg.secretLister.Secrets(namespace).Get(source.SecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		key := sourceKey(source.SecretRef)
+		if value, ok := secret.Data[key]; ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("key %s not found in secret %s/%s", key, namespace, secret.Name)
+	case source.ConfigMapRef != nil:
+		configMap, err := kubecfg.configMapLister.ConfigMaps(namespace).Get(source.ConfigMapRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		key := sourceKey(source.ConfigMapRef)
+		if value, ok := configMap.Data[key]; ok {
+			return []byte(value), nil
+		}
+		if value, ok := configMap.BinaryData[key]; ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("key %s not found in configmap %s/%s", key, namespace, configMap.Name)
+	}
+	return nil, fmt.Errorf("kubeconfigFrom needs a secretRef or a configMapRef")
+}
+
+func sourceKey(ref *v1alpha1.KeyReference) string {
+	if ref.Key == "" {
+		return defaultSourceKey
+	}
+	return ref.Key
+}
+
+// Reconciles the Kubeconfigs using a Secret or a ConfigMap of the informers when it changes
+func (r *Reconciler) watchSources(informers ...cache.SharedIndexInformer) error {
+	for _, informer := range informers {
+		_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    r.enqueueSourceUsers,
+			UpdateFunc: func(_, obj any) { r.enqueueSourceUsers(obj) },
+			DeleteFunc: r.enqueueSourceUsers,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) enqueueSourceUsers(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	_, isSecret := obj.(*corev1.Secret)
+
+	kubeconfigs, err := kubecfg.lister.Kubeconfigs(object.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, kubeconfig := range kubeconfigs {
+		source := kubeconfig.Spec.KubeconfigFrom
+		if source == nil {
+			continue
+		}
+		if isSecret && source.SecretRef != nil && source.SecretRef.Name == object.GetName() ||
+			!isSecret && source.ConfigMapRef != nil && source.ConfigMapRef.Name == object.GetName() {
+			r.enqueue(kubeconfig)
+		}
+	}
+}
+
+// Returns the namespaces whose Secrets and ConfigMaps are watched. Outside of the pod namespace and
+// the watched namespaces listed in the configuration, their access is only granted cluster-wide.
+func sourceNamespaces() []string {
+	podNamespace := viper.GetString(podNamespaceKey)
+	if !watchesMultipleNamespaces() {
+		return []string{podNamespace}
+	}
+	if viper.GetBool(watchSecretsClusterWideKey) {
+		return []string{metav1.NamespaceAll}
+	}
+	namespaces := []string{podNamespace}
+	for _, namespace := range splitList(viper.GetString(watchNamespacesKey)) {
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// Lists the Secrets of several namespaces, each one watched by its own informer
+type namespacedSecretLister map[string]corelisters.SecretLister
+
+func (l namespacedSecretLister) List(selector labels.Selector) ([]*corev1.Secret, error) {
+	var secrets []*corev1.Secret
+	for _, lister := range l {
+		list, err := lister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, list...)
+	}
+	return secrets, nil
+}
+
+func (l namespacedSecretLister) Secrets(namespace string) corelisters.SecretNamespaceLister {
+	if lister, ok := l[namespace]; ok {
+		return lister.Secrets(namespace)
+	}
+	if lister, ok := l[metav1.NamespaceAll]; ok {
+		return lister.Secrets(namespace)
+	}
+	// Secrets of unwatched namespaces are not found
+	return corelisters.NewSecretLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})).Secrets(namespace)
+}
+
+// Lists the ConfigMaps of several namespaces, each one watched by its own informer
+type namespacedConfigMapLister map[string]corelisters.ConfigMapLister
+
+func (l namespacedConfigMapLister) List(selector labels.Selector) ([]*corev1.ConfigMap, error) {
+	var configMaps []*corev1.ConfigMap
+	for _, lister := range l {
+		list, err := lister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		configMaps = append(configMaps, list...)
+	}
+	return configMaps, nil
+}
+
+func (l namespacedConfigMapLister) ConfigMaps(namespace string) corelisters.ConfigMapNamespaceLister {
+	if lister, ok := l[namespace]; ok {
+		return lister.ConfigMaps(namespace)
+	}
+	if lister, ok := l[metav1.NamespaceAll]; ok {
+		return lister.ConfigMaps(namespace)
+	}
+	// ConfigMaps of unwatched namespaces are not found
+	return corelisters.NewConfigMapLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})).ConfigMaps(namespace)
+}
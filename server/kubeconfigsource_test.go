@@ -0,0 +1,120 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSourceNamespaces(t *testing.T) {
+	tests := []struct {
+		name              string
+		watchNamespaces   string
+		namespaceSelector string
+		clusterWide       bool
+		want              []string
+	}{
+		{
+			name: "pod namespace only",
+			want: []string{"kubebrowser"},
+		},
+		{
+			name:        "cluster-wide without watched namespaces",
+			clusterWide: true,
+			want:        []string{"kubebrowser"},
+		},
+		{
+			name:            "watched namespaces",
+			watchNamespaces: "team-a, kubebrowser,team-b,team-a",
+			want:            []string{"kubebrowser", "team-a", "team-b"},
+		},
+		{
+			name:              "namespace selector",
+			namespaceSelector: "kubebrowser.io/enabled=true",
+			want:              []string{"kubebrowser"},
+		},
+		{
+			name:            "cluster-wide",
+			watchNamespaces: "team-a",
+			clusterWide:     true,
+			want:            []string{metav1.NamespaceAll},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(podNamespaceKey, "kubebrowser")
+			viper.Set(watchNamespacesKey, tt.watchNamespaces)
+			viper.Set(watchNamespaceSelectorKey, tt.namespaceSelector)
+			viper.Set(watchSecretsClusterWideKey, tt.clusterWide)
+			t.Cleanup(func() {
+				viper.Set(podNamespaceKey, "")
+				viper.Set(watchNamespacesKey, "")
+				viper.Set(watchNamespaceSelectorKey, "")
+				viper.Set(watchSecretsClusterWideKey, false)
+			})
+
+			if got := sourceNamespaces(); !slices.Equal(got, tt.want) {
+				t.Errorf("sourceNamespaces() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespacedSecretLister(t *testing.T) {
+	secretLister := func(secrets ...*corev1.Secret) corelisters.SecretLister {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for _, secret := range secrets {
+			if err := indexer.Add(secret); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return corelisters.NewSecretLister(indexer)
+	}
+	secret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	tests := []struct {
+		name      string
+		lister    namespacedSecretLister
+		namespace string
+		wantFound bool
+	}{
+		{
+			name:      "watched namespace",
+			lister:    namespacedSecretLister{"team-a": secretLister(secret("team-a", "source"))},
+			namespace: "team-a",
+			wantFound: true,
+		},
+		{
+			name:      "unwatched namespace",
+			lister:    namespacedSecretLister{"team-a": secretLister(secret("team-a", "source"))},
+			namespace: "team-b",
+		},
+		{
+			name:      "cluster-wide",
+			lister:    namespacedSecretLister{metav1.NamespaceAll: secretLister(secret("team-b", "source"))},
+			namespace: "team-b",
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.lister.Secrets(tt.namespace).Get("source")
+			if tt.wantFound && err != nil {
+				t.Errorf("Get() error = %v", err)
+			}
+			if !tt.wantFound && !apierrors.IsNotFound(err) {
+				t.Errorf("Get() error = %v, want NotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const leaseName = "kubebrowser-leader"
+
+// Runs the function on a single replica at a time. The function context is cancelled when the
+// leadership is lost.
+func runWithLeaderElection(ctx context.Context, run func(ctx context.Context)) {
+	if !viper.GetBool(leaderElectionKey) {
+		run(ctx)
+		return
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		logger.Errorf("Cannot get hostname for leader election: %s", err)
+		return
+	}
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		viper.GetString(podNamespaceKey),
+		leaseName,
+		kubecfg.kubeClient.CoreV1(),
+		kubecfg.kubeClient.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: identity},
+	)
+	if err != nil {
+		logger.Errorf("Cannot create leader election lock: %s", err)
+		return
+	}
+
+	// Keep trying to acquire the leadership until the server stops
+	for ctx.Err() == nil {
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			ReleaseOnCancel: true,
+			LeaseDuration:   15 * time.Second,
+			RenewDeadline:   10 * time.Second,
+			RetryPeriod:     2 * time.Second,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: run,
+				OnStoppedLeading: func() {
+					logger.Info("Stopped leading")
+				},
+				OnNewLeader: func(leader string) {
+					logger.Infow("New leader elected", "leader", leader)
+				},
+			},
+		})
+	}
+}
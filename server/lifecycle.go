@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-gonic/gin"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// Date format of the sunset dates in warnings
+const sunsetDateFormat = "2006-01-02"
+
+// Returns the lifecycle state of a Kubeconfig, active if unset
+func kubeconfigState(kubeconfig *v1alpha1.Kubeconfig) v1alpha1.LifecycleState {
+	if kubeconfig.Spec.Lifecycle == nil || kubeconfig.Spec.Lifecycle.State == "" {
+		return v1alpha1.LifecycleActive
+	}
+	return kubeconfig.Spec.Lifecycle.State
+}
+
+// Returns whether a Kubeconfig must not be served anymore
+func kubeconfigDisabled(kubeconfig *v1alpha1.Kubeconfig) bool {
+	return kubeconfigState(kubeconfig) == v1alpha1.LifecycleDisabled
+}
+
+// Returns the warnings shown to the users of a Kubeconfig, telling when it is deprecated, its
+// sunset date and its replacement
+func kubeconfigWarnings(kubeconfig *v1alpha1.Kubeconfig) []string {
+	if kubeconfigState(kubeconfig) != v1alpha1.LifecycleDeprecated {
+		return nil
+	}
+	lifecycle := kubeconfig.Spec.Lifecycle
+
+	warning := fmt.Sprintf("Kubeconfig %s is deprecated", kubeconfigID(kubeconfig))
+	if lifecycle.SunsetDate != nil {
+		warning += " and will be removed on " + lifecycle.SunsetDate.UTC().Format(sunsetDateFormat)
+	}
+	if lifecycle.Replacement != "" {
+		warning += ", use " + lifecycle.Replacement + " instead"
+	}
+	if lifecycle.Message != "" {
+		warning += ": " + lifecycle.Message
+	}
+	return []string{warning}
+}
+
+// Adds a Warning header for each warning of the views, as the Kubernetes API server does
+func setWarningHeaders(c *gin.Context, views []KubeconfigView) {
+	for _, view := range views {
+		for _, warning := range view.Warnings {
+			// Header values cannot hold line breaks
+			header, err := utilnet.NewWarningHeader(299, "-", strings.Join(strings.Fields(warning), " "))
+			if err != nil {
+				logger.Warnw("Skipping invalid warning", "warning", warning, "error", err)
+				continue
+			}
+			c.Writer.Header().Add("Warning", header)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Returns a Kubeconfig with the lifecycle
+func testLifecycleKubeconfig(name string, lifecycle *v1alpha1.Lifecycle) *v1alpha1.Kubeconfig {
+	spec := testKubeconfigSpec(name)
+	spec.Lifecycle = lifecycle
+	return &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+}
+
+func TestKubeconfigState(t *testing.T) {
+	tests := []struct {
+		name      string
+		lifecycle *v1alpha1.Lifecycle
+		want      v1alpha1.LifecycleState
+	}{
+		{name: "no lifecycle", lifecycle: nil, want: v1alpha1.LifecycleActive},
+		{name: "empty state", lifecycle: &v1alpha1.Lifecycle{Message: "upgrade"}, want: v1alpha1.LifecycleActive},
+		{name: "maintenance", lifecycle: &v1alpha1.Lifecycle{State: v1alpha1.LifecycleMaintenance}, want: v1alpha1.LifecycleMaintenance},
+		{name: "disabled", lifecycle: &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDisabled}, want: v1alpha1.LifecycleDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kubeconfigState(testLifecycleKubeconfig("prod", tt.lifecycle)); got != tt.want {
+				t.Errorf("kubeconfigState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeconfigWarnings(t *testing.T) {
+	sunset := metav1.NewTime(time.Date(2026, time.December, 31, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name      string
+		lifecycle *v1alpha1.Lifecycle
+		want      []string
+	}{
+		{
+			name:      "active",
+			lifecycle: nil,
+			want:      nil,
+		},
+		{
+			name:      "maintenance",
+			lifecycle: &v1alpha1.Lifecycle{State: v1alpha1.LifecycleMaintenance, Message: "upgrade"},
+			want:      nil,
+		},
+		{
+			name:      "deprecated",
+			lifecycle: &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDeprecated},
+			want:      []string{"Kubeconfig prod is deprecated"},
+		},
+		{
+			name: "deprecated with sunset date, replacement and message",
+			lifecycle: &v1alpha1.Lifecycle{
+				State:       v1alpha1.LifecycleDeprecated,
+				SunsetDate:  &sunset,
+				Replacement: "prod-v2",
+				Message:     "migrate before the end of the year",
+			},
+			want: []string{"Kubeconfig prod is deprecated and will be removed on 2026-12-31, use prod-v2 instead: migrate before the end of the year"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kubeconfigWarnings(testLifecycleKubeconfig("prod", tt.lifecycle)); !slices.Equal(got, tt.want) {
+				t.Errorf("kubeconfigWarnings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleFiltering(t *testing.T) {
+	kubeconfigs := []*v1alpha1.Kubeconfig{
+		testLifecycleKubeconfig("active", nil),
+		testLifecycleKubeconfig("maintenance", &v1alpha1.Lifecycle{State: v1alpha1.LifecycleMaintenance}),
+		testLifecycleKubeconfig("deprecated", &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDeprecated}),
+		testLifecycleKubeconfig("disabled", &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDisabled}),
+	}
+
+	tests := []struct {
+		name   string
+		states []string
+		want   []string
+	}{
+		{name: "all states", states: nil, want: []string{"active", "deprecated", "maintenance"}},
+		{name: "active", states: []string{"active"}, want: []string{"active"}},
+		{name: "deprecated or maintenance", states: []string{"deprecated", "maintenance"}, want: []string{"deprecated", "maintenance"}},
+		{name: "disabled", states: []string{"disabled"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served := slices.DeleteFunc(slices.Clone(kubeconfigs), func(kubeconfig *v1alpha1.Kubeconfig) bool {
+				return !servedKubeconfig(kubeconfig)
+			})
+			query := &kubeconfigQuery{selector: labels.Everything(), fields: map[string][]string{}, sortField: "id"}
+			if tt.states != nil {
+				query.fields["state"] = tt.states
+			}
+			page, total, _ := query.apply(served)
+
+			ids := make([]string, 0, len(page))
+			for _, view := range toKubeConfigViews(page, "id-token", "refresh-token") {
+				ids = append(ids, view.ID)
+			}
+			if !slices.Equal(ids, tt.want) || total != len(tt.want) {
+				t.Errorf("served = %q (total %d), want %q", ids, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestToKubeConfigViewsSkipsDisabled(t *testing.T) {
+	kubeconfigs := []*v1alpha1.Kubeconfig{
+		testLifecycleKubeconfig("active", nil),
+		testLifecycleKubeconfig("disabled", &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDisabled}),
+		testLifecycleKubeconfig("deprecated", &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDeprecated}),
+	}
+
+	views := toKubeConfigViews(kubeconfigs, "id-token", "refresh-token")
+	if len(views) != 2 || views[0].ID != "active" || views[1].ID != "deprecated" {
+		t.Fatalf("toKubeConfigViews() returned %d views, want active and deprecated", len(views))
+	}
+	if len(views[0].Warnings) != 0 {
+		t.Errorf("active view warnings = %q, want none", views[0].Warnings)
+	}
+	if len(views[1].Warnings) != 1 {
+		t.Errorf("deprecated view warnings = %q, want one", views[1].Warnings)
+	}
+}
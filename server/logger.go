@@ -10,6 +10,11 @@ import (
 
 var logger *zap.SugaredLogger
 
+// Logs a security relevant event, such as a rejected login
+func audit(msg string, keysAndValues ...any) {
+	logger.Named("audit").Infow(msg, keysAndValues...)
+}
+
 func InitLogger() error {
 	isDev := viper.GetBool(devKey)
 	logLevel := viper.GetString(logLevelKey)
@@ -26,7 +31,7 @@ func InitLogger() error {
 func newLogger(logLevel string, isDev bool) (*zap.Logger, error) {
 	level, err := zapcore.ParseLevel(logLevel)
 	if err != nil {
-		fmt.Println("Unknown log level '%s', falling back to INFO", logLevel)
+		fmt.Printf("Unknown log level '%s', falling back to INFO\n", logLevel)
 		level = zapcore.InfoLevel
 	}
 
@@ -2,19 +2,24 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"regexp"
+	"slices"
+	"strconv"
 	"time"
 
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
 	"github.com/AvistoTelecom/kubebrowser/pkg/signals"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/memstore"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
-	"k8s.io/apimachinery/pkg/labels"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 var static = os.Getenv("KO_DATA_PATH")
@@ -29,6 +34,58 @@ const (
 	clientIDKey      = "oauth2_client_id"
 	clientSecretKey  = "oauth2_client_secret"
 	issuerURLKey     = "oauth2_issuer_url"
+
+	accessRequestDefaultDurationKey = "access_request_default_duration"
+	accessRequestMaxDurationKey     = "access_request_max_duration"
+	accessExpiryIntervalKey         = "access_expiry_interval"
+	adminGroupKey                   = "admin_group"
+	breakGlassGroupKey              = "breakglass_group"
+	breakGlassDurationKey           = "breakglass_duration"
+	breakGlassWebhookURLKey         = "breakglass_webhook_url"
+	allowedEmailDomainsKey          = "allowed_email_domains"
+	requiredGroupsKey               = "required_groups"
+	requireEmailVerifiedKey         = "require_email_verified"
+	leaderElectionKey               = "leader_election"
+	reconcilerWorkersKey            = "reconciler_workers"
+	webhookEnabledKey               = "webhook_enabled"
+	webhookPortKey                  = "webhook_port"
+	webhookCertDirKey               = "webhook_cert_dir"
+	watchNamespacesKey              = "watch_namespaces"
+	watchNamespaceSelectorKey       = "watch_namespace_selector"
+	watchSecretsClusterWideKey      = "watch_secrets_cluster_wide"
+	capiEnabledKey                  = "capi_enabled"
+	capiClusterSelectorKey          = "capi_cluster_selector"
+	argoCDEnabledKey                = "argocd_enabled"
+	argoCDNamespaceKey              = "argocd_namespace"
+	argoCDSecretSelectorKey         = "argocd_secret_selector"
+	catalogProviderKey              = "catalog_provider"
+	catalogDirectoryKey             = "catalog_directory"
+	catalogGitURLKey                = "catalog_git_url"
+	catalogGitRefKey                = "catalog_git_ref"
+	catalogGitPathKey               = "catalog_git_path"
+	catalogGitIntervalKey           = "catalog_git_interval"
+	catalogGitWebhookTokenKey       = "catalog_git_webhook_token"
+	catalogSourcesKey               = "catalog_sources"
+	catalogSourceProbeIntervalKey   = "catalog_source_probe_interval"
+	kubeconfigKey                   = "kubeconfig"
+	kubeContextKey                  = "kube_context"
+	informerResyncKey               = "informer_resync"
+	eventsMaxConnectionsKey         = "events_max_connections"
+	eventsMaxUserConnectionsKey     = "events_max_user_connections"
+	eventsHeartbeatIntervalKey      = "events_heartbeat_interval"
+	probeIntervalKey                = "probe_interval"
+	metricsEnabledKey               = "metrics_enabled"
+	metricsPortKey                  = "metrics_port"
+	caExpiryWarningWindowKey        = "ca_expiry_warning_window"
+	caExpiryCheckIntervalKey        = "ca_expiry_check_interval"
+)
+
+// Providers of the catalog of Kubeconfigs
+const (
+	catalogProviderKubernetes = "kubernetes"
+	catalogProviderDirectory  = "directory"
+	catalogProviderGit        = "git"
+	catalogProviderFederated  = "federated"
 )
 
 const (
@@ -43,13 +100,46 @@ func init() {
 	viper.SetDefault(sessionSecretKey, "changeme")
 	viper.SetDefault(devKey, false)
 	viper.SetDefault(logLevelKey, "INFO")
+	viper.SetDefault(accessRequestDefaultDurationKey, 8*time.Hour)
+	viper.SetDefault(accessRequestMaxDurationKey, 7*24*time.Hour)
+	viper.SetDefault(accessExpiryIntervalKey, time.Minute)
+	viper.SetDefault(breakGlassDurationKey, time.Hour)
+	viper.SetDefault(requireEmailVerifiedKey, false)
+	viper.SetDefault(leaderElectionKey, true)
+	viper.SetDefault(reconcilerWorkersKey, 2)
+	viper.SetDefault(webhookEnabledKey, false)
+	viper.SetDefault(webhookPortKey, 9443)
+	viper.SetDefault(webhookCertDirKey, "/etc/kubebrowser/webhook")
+	viper.SetDefault(watchSecretsClusterWideKey, false)
+	viper.SetDefault(capiEnabledKey, false)
+	viper.SetDefault(argoCDEnabledKey, false)
+	viper.SetDefault(argoCDNamespaceKey, "argocd")
+	viper.SetDefault(catalogProviderKey, catalogProviderKubernetes)
+	viper.SetDefault(catalogGitIntervalKey, 5*time.Minute)
+	viper.SetDefault(catalogSourceProbeIntervalKey, 30*time.Second)
+	viper.SetDefault(informerResyncKey, 30*time.Second)
+	viper.SetDefault(eventsMaxConnectionsKey, 1000)
+	viper.SetDefault(eventsMaxUserConnectionsKey, 5)
+	viper.SetDefault(eventsHeartbeatIntervalKey, defaultEventsHeartbeatInterval)
+	viper.SetDefault(probeIntervalKey, time.Minute)
+	viper.SetDefault(metricsEnabledKey, false)
+	viper.SetDefault(metricsPortKey, 9090)
+	viper.SetDefault(caExpiryWarningWindowKey, 30*24*time.Hour)
+	viper.SetDefault(caExpiryCheckIntervalKey, time.Hour)
+
+	pflag.String(kubeconfigKey, "", "Path to the kubeconfig of the cluster, instead of the in-cluster configuration")
+	pflag.String("context", "", "Context of the kubeconfig to use, its current context if empty")
+	viper.BindPFlag(kubeconfigKey, pflag.Lookup(kubeconfigKey))
+	viper.BindPFlag(kubeContextKey, pflag.Lookup("context"))
 }
 
 func main() {
+	pflag.Parse()
 	if err := InitLogger(); err != nil {
 		panic(err)
 	}
 	defer logger.Sync()
+	admissionPolicy = loadAdmissionPolicy()
 
 	// Set up signals so we handle the shutdown signal gracefully
 	ctx := signals.SetupSignalHandler()
@@ -60,6 +150,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Probe the clusters of the catalog on every replica, so that each one serves the results
+	if interval := viper.GetDuration(probeIntervalKey); interval > 0 {
+		go clusterProbes.Run(ctx, interval)
+	}
+	if viper.GetBool(metricsEnabledKey) {
+		go runMetricsServer(ctx)
+	}
+
+	if kubecfg.HasCluster() {
+		// Run background tasks that write to the Kubernetes API on the leader replica only
+		go runWithLeaderElection(ctx, func(ctx context.Context) {
+			go runAccessExpiry(ctx)
+			go runCertificateAuthorityExpiry(ctx)
+			kubecfg.reconciler.Run(ctx, viper.GetInt(reconcilerWorkersKey))
+		})
+
+		// Serve the validating admission webhook on all replicas
+		if viper.GetBool(webhookEnabledKey) {
+			go runWebhookServer(ctx)
+		}
+	}
+
 	// Create OIDC related config and verifier
 	err := InitOIDC(ctx)
 	if err != nil {
@@ -83,6 +195,10 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	if viper.GetString(catalogGitWebhookTokenKey) != "" {
+		router.POST("/api/catalog/sync", handleSyncCatalog)
+	}
+
 	router.GET("/", func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/home")
 	})
@@ -91,7 +207,20 @@ func main() {
 	authorized.StaticFS("/home", http.Dir(static))
 	authorized.GET(callbackRoute, handleOAuth2Callback)
 	authorized.GET("/api/kubeconfigs", handleGetKubeconfigs)
+	authorized.GET("/api/kubeconfigs/events", handleKubeconfigEvents)
 	authorized.GET("/api/me", handleGetMe)
+	authorized.GET("/api/catalog", handleGetCatalogStatus)
+	authorized.GET("/api/announcements", handleGetAnnouncements)
+	authorized.GET(stepUpRoute+"*id", handleStepUp)
+	if kubecfg.HasCluster() {
+		authorized.GET("/api/accessrequests", handleGetAccessRequests)
+		authorized.GET("/api/accessrequests/requestable", handleGetRequestableKubeconfigs)
+		authorized.POST("/api/accessrequests", handleCreateAccessRequest)
+		authorized.POST("/api/accessrequests/:name/approve", handleApproveAccessRequest)
+		authorized.POST("/api/accessrequests/:name/deny", handleDenyAccessRequest)
+		authorized.GET("/api/breakglass", handleGetBreakGlassGrants)
+		authorized.POST("/api/breakglass", handleCreateBreakGlassGrant)
+	}
 
 	srv := &http.Server{
 		Addr:    ":" + defaultPort,
@@ -122,61 +251,150 @@ func main() {
 	logger.Warn("Server exiting")
 }
 
+// Lists the Kubeconfigs of the user, searched, filtered, sorted and paginated as requested by
+// the query parameters
 func handleGetKubeconfigs(c *gin.Context) {
 	logger.Debug("Getting kubeconfig")
 
-	session := sessions.Default(c)
-	rawIDToken := session.Get(rawIDTokenKey).(string)
-	refreshToken := session.Get(refreshTokenKey).(string)
+	query, err := parseKubeconfigQuery(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, "Invalid query: %s", err)
+		return
+	}
 
-	// NOTE: verification has been done in AuthMiddleware already
-	idToken, err := oauth2Verifier.Verify(c.Request.Context(), rawIDToken)
+	kubeconfigs, err := authorizedKubeconfigs(c)
 	if err != nil {
-		logger.Error(err, "Error preparing kubeconfigs")
+		logger.Errorf("Error preparing kubeconfigs: %s", err)
 		c.String(http.StatusInternalServerError, "Error preparing kubeconfigs")
 		return
 	}
 
-	var claims EmailAndGroups
-	if err := idToken.Claims(&claims); err != nil {
-		logger.Error(err, "Error preparing kubeconfigs")
+	page, total, next := query.apply(kubeconfigs)
+	views, err := renderKubeconfigViews(c, page)
+	if err != nil {
+		logger.Errorf("Error preparing kubeconfigs: %s", err)
 		c.String(http.StatusInternalServerError, "Error preparing kubeconfigs")
 		return
 	}
+
+	setWarningHeaders(c, views)
+	c.Header(totalCountHeader, strconv.Itoa(total))
+	if next != "" {
+		c.Header(continueHeader, next)
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// Returns the Kubeconfigs the user of the session can access, rendered with their credentials
+// unless a step-up login is required
+func userKubeconfigViews(c *gin.Context) ([]KubeconfigView, error) {
+	kubeconfigs, err := authorizedKubeconfigs(c)
+	if err != nil {
+		return nil, err
+	}
+	return renderKubeconfigViews(c, kubeconfigs)
+}
+
+// Returns the Kubeconfigs of the catalog the user of the session can access
+func authorizedKubeconfigs(c *gin.Context) ([]*v1alpha1.Kubeconfig, error) {
+	claims, err := userClaims(c)
+	if err != nil {
+		return nil, err
+	}
 	logger.Debugw("Extracted claims", "claims", claims)
 
 	logger.Debug("Getting list of all kube configs")
-	configs, err := kubecfg.lister.Kubeconfigs(viper.GetString(podNamespaceKey)).List(labels.Everything())
+	configs, err := listKubeconfigs()
+	if err != nil {
+		return nil, fmt.Errorf("listing kubeconfigs: %w", err)
+	}
+
+	grants, err := listAccessGrants()
 	if err != nil {
-		logger.Errorf("Error listing kubeconfigs: %s", err)
-		c.String(http.StatusInternalServerError, "Error listing kubeconfigs")
+		return nil, fmt.Errorf("listing access grants: %w", err)
 	}
 
-	filtered := filterKubeConfigs(configs, claims)
-	user := kubeConfigUser(rawIDToken, refreshToken)
-	specs := toKubeConfigSpecs(filtered, user)
+	// Left out before paging, so that Kubeconfigs that are disabled or cannot be rendered are not
+	// counted
+	configs = slices.DeleteFunc(configs, func(kubeconfig *v1alpha1.Kubeconfig) bool {
+		return !servedKubeconfig(kubeconfig)
+	})
+	return filterKubeConfigs(configs, claims, grants), nil
+}
 
-	c.JSON(http.StatusOK, specs)
+// Returns the Kubeconfig with this ID if the user of the session can access it, and a NotFound
+// error otherwise
+func authorizedKubeconfig(c *gin.Context, id string) (*v1alpha1.Kubeconfig, error) {
+	claims, err := userClaims(c)
+	if err != nil {
+		return nil, err
+	}
+	return visibleKubeconfig(id, claims)
 }
 
-func handleGetMe(c *gin.Context) {
-	logger.Debug("Entering handleGetMe")
+// Returns the Kubeconfig with this ID if the user can access it. Kubeconfigs hidden from the user
+// are not found, so that their existence is not revealed.
+func visibleKubeconfig(id string, claims EmailAndGroups) (*v1alpha1.Kubeconfig, error) {
+	kubeconfig, err := getKubeconfig(id)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := listAccessGrants()
+	if err != nil {
+		return nil, fmt.Errorf("listing access grants: %w", err)
+	}
 
+	if !servedKubeconfig(kubeconfig) || len(filterKubeConfigs([]*v1alpha1.Kubeconfig{kubeconfig}, claims, grants)) == 0 {
+		return nil, apierrors.NewNotFound(v1alpha1.Resource("kubeconfigs"), id)
+	}
+	return kubeconfig, nil
+}
+
+// Renders the Kubeconfigs with the credentials of the session, unless a step-up login is required
+func renderKubeconfigViews(c *gin.Context, kubeconfigs []*v1alpha1.Kubeconfig) ([]KubeconfigView, error) {
 	session := sessions.Default(c)
 	rawIDToken := session.Get(rawIDTokenKey).(string)
+	refreshToken := session.Get(refreshTokenKey).(string)
 
-	// NOTE: verification has been done in AuthMiddleware already
-	idToken, err := oauth2Verifier.Verify(c.Request.Context(), rawIDToken)
-	if err != nil {
-		logger.Errorf("Error verifying ID Token: %s", err)
-		c.String(http.StatusInternalServerError, "Error verifying ID Token")
+	var authClaims AuthClaims
+	if err := sessionClaims(c, &authClaims); err != nil {
+		return nil, err
+	}
+
+	views := toKubeConfigViews(kubeconfigs, rawIDToken, refreshToken)
+
+	now := time.Now()
+	for i, view := range views {
+		if !stepUpSatisfied(view.KubeconfigSpec, authClaims, now) {
+			view.Kubeconfig.Users = nil // Never hand out credentials before step-up
+			views[i].StepUpURL = stepUpRoute + view.ID
+		}
 	}
+	return views, nil
+}
+
+func handleGetMe(c *gin.Context) {
+	logger.Debug("Entering handleGetMe")
 
-	var claims NameOnly
-	if err := idToken.Claims(&claims); err != nil {
+	var claims Profile
+	if err := sessionClaims(c, &claims); err != nil {
 		logger.Errorf("Error extracting claims: %s", err)
 		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	aliases, err := groupAliases()
+	if err != nil {
+		logger.Errorf("Error listing group aliases: %s", err)
+		c.String(http.StatusInternalServerError, "Error listing group aliases")
+		return
+	}
+
+	groups := make([]Group, 0, len(claims.Groups))
+	for _, id := range claims.Groups {
+		groups = append(groups, Group{ID: id, DisplayName: aliases[id]})
 	}
 
-	c.JSON(http.StatusOK, claims.Name)
+	c.JSON(http.StatusOK, gin.H{"name": claims.Name, "email": claims.Email, "groups": groups})
 }
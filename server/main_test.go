@@ -0,0 +1,15 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+func TestMain(m *testing.M) {
+	logger = zap.NewNop().Sugar()
+	oauth2Config = &oauth2.Config{ClientID: "kubebrowser"}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+)
+
+const metricsNamespace = "kubebrowser"
+
+// Labels of the metrics of a cluster
+var clusterMetricLabels = []string{"kubeconfig", "cluster"}
+
+var (
+	clusterUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_up",
+		Help:      "Whether the API server of the cluster answered the last probe.",
+	}, clusterMetricLabels)
+	clusterReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_ready",
+		Help:      "Whether the API server of the cluster answered its readiness request with 200, absent when it refused an anonymous request.",
+	}, clusterMetricLabels)
+	clusterProbeDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_probe_duration_seconds",
+		Help:      "Duration of the version request of the last probe of the cluster, TLS handshake included.",
+	}, clusterMetricLabels)
+	clusterCertificateValid = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_certificate_valid",
+		Help:      "Whether the certificates of the API server of the cluster are within their validity period and match its server name.",
+	}, clusterMetricLabels)
+	clusterCertificateAuthorityValid = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_certificate_authority_valid",
+		Help:      "Whether the certificate authority data of the cluster validates the certificate of its API server.",
+	}, clusterMetricLabels)
+	clusterInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_info",
+		Help:      "Kubernetes version of the API server of the cluster, always 1.",
+	}, append(clusterMetricLabels, "version"))
+	clusterCertificateAuthorityExpiry = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "cluster_certificate_authority_expiry_timestamp_seconds"),
+		"Expiry of the first certificate to expire in the certificate authority data of the cluster, in seconds since the epoch.",
+		clusterMetricLabels, nil,
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		clusterUp,
+		clusterReady,
+		clusterProbeDuration,
+		clusterCertificateValid,
+		clusterCertificateAuthorityValid,
+		clusterInfo,
+		certificateAuthorityCollector{},
+	)
+}
+
+// Label values of the series set by the last probe of the catalog, by gauge
+var probeMetricSeries = map[*prometheus.GaugeVec]map[string][]string{}
+
+// Replaces the metrics of the clusters with the results of a probe of the catalog. The series the
+// probe did not set are deleted afterwards, so that a scrape never misses the others.
+func recordProbeMetrics(probes map[string][]ClusterProbe) {
+	series := map[*prometheus.GaugeVec]map[string][]string{}
+	set := func(gauge *prometheus.GaugeVec, value float64, labelValues ...string) {
+		gauge.WithLabelValues(labelValues...).Set(value)
+		if series[gauge] == nil {
+			series[gauge] = map[string][]string{}
+		}
+		series[gauge][strings.Join(labelValues, "\x00")] = labelValues
+	}
+
+	for id, results := range probes {
+		for _, probe := range results {
+			set(clusterUp, boolMetric(probe.Reachable), id, probe.Name)
+			if !probe.Reachable {
+				continue
+			}
+			set(clusterProbeDuration, float64(probe.LatencyMilliseconds)/1000, id, probe.Name)
+			if probe.Ready != nil {
+				set(clusterReady, boolMetric(*probe.Ready), id, probe.Name)
+			}
+			if probe.CertificateValid != nil {
+				set(clusterCertificateValid, boolMetric(*probe.CertificateValid), id, probe.Name)
+			}
+			if probe.CertificateAuthorityValid != nil {
+				set(clusterCertificateAuthorityValid, boolMetric(*probe.CertificateAuthorityValid), id, probe.Name)
+			}
+			if probe.Version != "" {
+				set(clusterInfo, 1, id, probe.Name, probe.Version)
+			}
+		}
+	}
+
+	for gauge, previous := range probeMetricSeries {
+		for key, labelValues := range previous {
+			if _, ok := series[gauge][key]; !ok {
+				gauge.DeleteLabelValues(labelValues...)
+			}
+		}
+	}
+	probeMetricSeries = series
+}
+
+// Computes the expiry of the certificate authorities of the catalog on scrape, so that it does not
+// depend on probing
+type certificateAuthorityCollector struct{}
+
+func (certificateAuthorityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterCertificateAuthorityExpiry
+}
+
+func (certificateAuthorityCollector) Collect(ch chan<- prometheus.Metric) {
+	kubeconfigs, err := listKubeconfigs()
+	if err != nil {
+		logger.Errorf("Error listing kubeconfigs to collect certificate authorities: %s", err)
+		return
+	}
+	for _, kubeconfig := range kubeconfigs {
+		if kubeconfigDisabled(kubeconfig) {
+			continue
+		}
+		id := kubeconfigID(kubeconfig)
+		for _, expiry := range certificateAuthorityExpiries(kubeconfig) {
+			ch <- prometheus.MustNewConstMetric(clusterCertificateAuthorityExpiry, prometheus.GaugeValue,
+				float64(expiry.NotAfter.Unix()), id, expiry.Cluster)
+		}
+	}
+}
+
+func boolMetric(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// Serves the Prometheus metrics until the context is cancelled
+func runMetricsServer(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{
+		Addr:    ":" + strconv.Itoa(viper.GetInt(metricsPortKey)),
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("Metrics server forced to shutdown: %s", err)
+		}
+	}()
+
+	logger.Infow("Start to serve metrics", "addr", srv.Addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Fatalf("Metrics server failed: %s", err)
+	}
+}
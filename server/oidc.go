@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ const (
 	initialRouteKey = "initial_route"
 	rawIDTokenKey   = "id_token"
 	refreshTokenKey = "refresh_token"
+	stepUpKey       = "step_up"
 )
 
 var oauth2Config *oauth2.Config
@@ -25,15 +27,35 @@ var oauth2Verifier *oidc.IDTokenVerifier
 
 // idToken Claims
 
-type NameOnly struct {
-	Name string
+type EmailAndGroups struct {
+	Email  string
+	Groups []string
+	// Group IDs by the display name of their GroupAlias, see resolveGroupAliases
+	aliasIDs map[string]string
 }
 
-type EmailAndGroups struct {
+type Profile struct {
+	Name   string
 	Email  string
 	Groups []string
 }
 
+// Extracts the claims of the ID token stored in session into v
+// NOTE: verification has been done in AuthMiddleware already
+func sessionClaims(c *gin.Context, v any) error {
+	session := sessions.Default(c)
+	rawIDToken, ok := session.Get(rawIDTokenKey).(string)
+	if !ok {
+		return errors.New("no ID token in session")
+	}
+
+	idToken, err := oauth2Verifier.Verify(c.Request.Context(), rawIDToken)
+	if err != nil {
+		return err
+	}
+	return idToken.Claims(v)
+}
+
 func setCallbackCookie(c *gin.Context, name, value string) {
 	c.SetSameSite(http.SameSiteLaxMode)
 	c.SetCookie(
@@ -80,7 +102,7 @@ func refreshTokens(ctx context.Context, config *oauth2.Config, refreshToken stri
 	return newToken, nil
 }
 
-func redirectToOIDCLogin(c *gin.Context) {
+func redirectToOIDCLogin(c *gin.Context, opts ...oauth2.AuthCodeOption) {
 	logger.Debug("Entering redirectToOIDCLogin")
 
 	state, err := randString(16)
@@ -99,7 +121,8 @@ func redirectToOIDCLogin(c *gin.Context) {
 	setCallbackCookie(c, "nonce", nonce)
 
 	// Redirect to OIDC login
-	c.Redirect(http.StatusFound, oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce)))
+	opts = append(opts, oidc.Nonce(nonce))
+	c.Redirect(http.StatusFound, oauth2Config.AuthCodeURL(state, opts...))
 }
 
 func handleOAuth2Callback(c *gin.Context) {
@@ -152,6 +175,10 @@ func handleOAuth2Callback(c *gin.Context) {
 		return
 	}
 
+	if !checkAdmission(c, idToken) {
+		return
+	}
+
 	session := sessions.Default(c)
 
 	session.Set(rawIDTokenKey, rawIDToken)
@@ -216,7 +243,7 @@ func AuthMiddleware(c *gin.Context) {
 		}
 
 		// Verify new ID token
-		_, err = oauth2Verifier.Verify(c.Request.Context(), newToken.Extra("id_token").(string))
+		idToken, err = oauth2Verifier.Verify(c.Request.Context(), newToken.Extra("id_token").(string))
 		if err != nil {
 			logger.Errorf("Failed to verify refreshed ID token, redirecting to login: %s", err)
 			redirectToOIDCLogin(c)
@@ -234,6 +261,11 @@ func AuthMiddleware(c *gin.Context) {
 		}
 	}
 
+	if !checkAdmission(c, idToken) {
+		c.Abort()
+		return
+	}
+
 	// Token is valid, proceed with the request
 	logger.Debug("Token is valid, proceed with the request")
 	c.Next()
@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessRequestList contains a list of AccessRequest objects
+type AccessRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessRequest `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessRequest is a request from a user to access a Kubeconfig they are not whitelisted for
+type AccessRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AccessRequestSpec   `json:"spec,omitempty"`
+	Status            AccessRequestStatus `json:"status,omitempty"`
+}
+
+// AccessRequestSpec defines the requested access
+type AccessRequestSpec struct {
+	// Name of the requested Kubeconfig, in the same namespace
+	Kubeconfig string `json:"kubeconfig"`
+	// Email of the requester
+	Requester string `json:"requester"`
+	Reason    string `json:"reason,omitempty"`
+	// How long the access should last once approved
+	Duration metav1.Duration `json:"duration"`
+}
+
+type AccessRequestPhase string
+
+const (
+	AccessRequestPending  AccessRequestPhase = "Pending"
+	AccessRequestApproved AccessRequestPhase = "Approved"
+	AccessRequestDenied   AccessRequestPhase = "Denied"
+	AccessRequestExpired  AccessRequestPhase = "Expired"
+)
+
+// +k8s:deepcopy-gen=true
+
+// AccessRequestStatus holds the decision taken on an AccessRequest
+type AccessRequestStatus struct {
+	Phase     AccessRequestPhase `json:"phase,omitempty"`
+	DecidedBy string             `json:"decidedBy,omitempty"`
+	DecidedAt *metav1.Time       `json:"decidedAt,omitempty"`
+	ExpiresAt *metav1.Time       `json:"expiresAt,omitempty"`
+	Message   string             `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessGrantList contains a list of AccessGrant objects
+type AccessGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessGrant `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessGrant gives a user temporary access to a Kubeconfig, on top of its whitelist
+type AccessGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AccessGrantSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AccessGrantSpec defines who can access which Kubeconfig until when
+type AccessGrantSpec struct {
+	// Name of the granted Kubeconfig, in the same namespace
+	Kubeconfig string `json:"kubeconfig"`
+	// Email of the granted user
+	User      string      `json:"user"`
+	ExpiresAt metav1.Time `json:"expiresAt"`
+	Reason    string      `json:"reason,omitempty"`
+	// Set when the user granted themselves an emergency access
+	BreakGlass bool `json:"breakGlass,omitempty"`
+	// Incident ticket justifying a break-glass access
+	TicketID string `json:"ticketID,omitempty"`
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AnnouncementList contains a list of Announcement objects
+type AnnouncementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Announcement `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Announcement is a banner shown to the users of the catalog, such as an upcoming maintenance
+type Announcement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AnnouncementSpec `json:"spec,omitempty"`
+}
+
+type AnnouncementSeverity string
+
+const (
+	AnnouncementInfo     AnnouncementSeverity = "info"
+	AnnouncementWarning  AnnouncementSeverity = "warning"
+	AnnouncementCritical AnnouncementSeverity = "critical"
+)
+
+// +k8s:deepcopy-gen=true
+
+// AnnouncementSpec defines the content of an Announcement, when and to whom it is shown
+type AnnouncementSpec struct {
+	// Defaults to info
+	Severity AnnouncementSeverity `json:"severity,omitempty"`
+	// Markdown text of the announcement
+	Body string `json:"body"`
+	// Shown from this time, immediately if unset
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Shown until this time, forever if unset
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Shown to every user if unset
+	Audience *AnnouncementAudience `json:"audience,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AnnouncementAudience selects the users an Announcement is shown to: the users and the members
+// of the groups listed, and the users who can access a Kubeconfig matching the selector
+type AnnouncementAudience struct {
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Selects Kubeconfigs by their labels
+	KubeconfigSelector *metav1.LabelSelector `json:"kubeconfigSelector,omitempty"`
+}
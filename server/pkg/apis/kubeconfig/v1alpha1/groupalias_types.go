@@ -0,0 +1,35 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GroupAliasList contains a list of GroupAlias objects
+type GroupAliasList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupAlias `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GroupAlias gives a friendly name to an opaque group ID emitted by the identity provider
+type GroupAlias struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GroupAliasSpec `json:"spec,omitempty"`
+}
+
+// GroupAliasSpec maps a group ID to its display name
+type GroupAliasSpec struct {
+	// Group ID as found in the groups claim of the ID token
+	ID string `json:"id"`
+	// Friendly name that can be used in whitelists and approvers
+	DisplayName string `json:"displayName"`
+}
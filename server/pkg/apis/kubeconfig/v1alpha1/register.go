@@ -17,6 +17,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Kubeconfig{},
 		&KubeconfigList{},
+		&AccessRequest{},
+		&AccessRequestList{},
+		&AccessGrant{},
+		&AccessGrantList{},
+		&GroupAlias{},
+		&GroupAliasList{},
+		&ClusterKubeconfig{},
+		&ClusterKubeconfigList{},
+		&Announcement{},
+		&AnnouncementList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -16,7 +17,6 @@ type KubeconfigList struct {
 }
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -24,7 +24,48 @@ type KubeconfigList struct {
 type Kubeconfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              KubeconfigSpec `json:"spec,omitempty"`
+	Spec              KubeconfigSpec   `json:"spec,omitempty"`
+	Status            KubeconfigStatus `json:"status,omitempty"`
+}
+
+// Condition types of a Kubeconfig
+const (
+	// The Kubeconfig is well formed and can be rendered
+	ConditionValid = "Valid"
+	// The API server of the Kubeconfig answers
+	ConditionReachable = "Reachable"
+)
+
+// +k8s:deepcopy-gen=true
+
+// KubeconfigStatus defines the observed state of Kubeconfig
+type KubeconfigStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// Result of the last probe of the API server of each cluster
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ClusterStatus is the result of a probe of the API server of a cluster
+type ClusterStatus struct {
+	Name string `json:"name"`
+	// Whether the API server answered the version request
+	Reachable bool `json:"reachable"`
+	// Whether the API server answered the readiness request with 200, unset when it refused an
+	// anonymous request
+	Ready *bool `json:"ready,omitempty"`
+	// Kubernetes version of the API server
+	Version string `json:"version,omitempty"`
+	// Whether the certificates of the API server are within their validity period and match its
+	// server name
+	CertificateValid *bool `json:"certificateValid,omitempty"`
+	// Whether the certificate authority data of the cluster, or the system roots without
+	// certificate authority data, validates the certificate of the API server
+	CertificateAuthorityValid *bool `json:"certificateAuthorityValid,omitempty"`
+	// Why the probe failed or the certificates are invalid
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -33,55 +74,176 @@ type Kubeconfig struct {
 type KubeconfigData struct {
 	APIVersion     string    `json:"apiVersion"`
 	Kind           string    `json:"kind"`
-	Clusters       []Cluster `json:"clusters"`
+	Clusters       []Cluster `json:"clusters,omitempty"`
 	Contexts       []Context `json:"contexts,omitempty"`
 	CurrentContext string    `json:"current-context,omitempty"`
 	Users          []User    `json:"users,omitempty"`
+	// Additional information for extenders of kubectl
+	Extensions []NamedExtension `json:"extensions,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
 
 // KubeconfigSpec defines the desired state of Kubeconfig
 type KubeconfigSpec struct {
-	Name       string         `json:"name"`
-	Kubeconfig KubeconfigData `json:"kubeconfig"`
-	Whitelist  *Whitelist     `json:"whitelist,omitempty"`
+	Name string `json:"name"`
+	// Description of the cluster in the catalog
+	Description string `json:"description,omitempty"`
+	// Environment of the cluster, such as prod, staging or dev
+	Environment string `json:"environment,omitempty"`
+	Region      string `json:"region,omitempty"`
+	// Cloud provider hosting the cluster, such as aws or on-premise
+	Provider string `json:"provider,omitempty"`
+	// Team owning the cluster
+	Owner string `json:"owner,omitempty"`
+	// How to reach the owners, such as an email address or a chat channel
+	Contact string `json:"contact,omitempty"`
+	// Links to the runbook, the dashboards or any other page about the cluster
+	Links []Link `json:"links,omitempty"`
+	// Free-form tags, usable to filter the catalog
+	Tags []string `json:"tags,omitempty"`
+	// Lifecycle of the cluster, active if unset
+	Lifecycle  *Lifecycle     `json:"lifecycle,omitempty"`
+	Kubeconfig KubeconfigData `json:"kubeconfig,omitempty"`
+	// Secret or ConfigMap holding the kubeconfig or the CA bundle of the clusters
+	KubeconfigFrom *KubeconfigSource `json:"kubeconfigFrom,omitempty"`
+	Whitelist      *Whitelist        `json:"whitelist,omitempty"`
+	// Users and groups allowed to approve AccessRequests on this Kubeconfig
+	Approvers *Whitelist `json:"approvers,omitempty"`
+	// Maximum age of the user authentication to render this Kubeconfig
+	RequireAuthAge *metav1.Duration `json:"requireAuthAge,omitempty"`
+	// Authentication context class reference the user must have logged in with
+	RequiredACR string `json:"requiredACR,omitempty"`
+}
+
+// Link to a page about a cluster
+type Link struct {
+	// Title of the link, such as Runbook or Dashboard
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type LifecycleState string
+
+const (
+	// The cluster is in use
+	LifecycleActive LifecycleState = "active"
+	// The cluster is temporarily unavailable or degraded, its Kubeconfig is still served
+	LifecycleMaintenance LifecycleState = "maintenance"
+	// The cluster is being decommissioned, its Kubeconfig is served with a warning
+	LifecycleDeprecated LifecycleState = "deprecated"
+	// The cluster is decommissioned, its Kubeconfig is not served anymore
+	LifecycleDisabled LifecycleState = "disabled"
+)
+
+// +k8s:deepcopy-gen=true
+
+// Lifecycle defines the state of a cluster, so it can be decommissioned without deleting its
+// Kubeconfig
+type Lifecycle struct {
+	// Defaults to active
+	State LifecycleState `json:"state,omitempty"`
+	// Shown to users, such as the reason and the expected end of a maintenance
+	Message string `json:"message,omitempty"`
+	// Date after which the cluster will not be available anymore
+	SunsetDate *metav1.Time `json:"sunsetDate,omitempty"`
+	// ID of the Kubeconfig replacing this one
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// KubeconfigSource references a key of a Secret or a ConfigMap in the namespace of the Kubeconfig,
+// or in the namespace of Kubebrowser for ClusterKubeconfigs. The key holds either a kubeconfig,
+// whose clusters and contexts are used, or a PEM CA bundle, set on every cluster.
+type KubeconfigSource struct {
+	SecretRef    *KeyReference `json:"secretRef,omitempty"`
+	ConfigMapRef *KeyReference `json:"configMapRef,omitempty"`
 }
 
+// KeyReference selects a key of a Secret or a ConfigMap
+type KeyReference struct {
+	Name string `json:"name"`
+	// Defaults to "kubeconfig"
+	Key string `json:"key,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // Cluster represents a Kubernetes cluster entry
 type Cluster struct {
 	Name    string  `json:"name"`
 	Cluster Details `json:"cluster"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // Details holds cluster connection details
 type Details struct {
 	Server                   string `json:"server"`
 	CertificateAuthorityData string `json:"certificate-authority-data,omitempty"`
-	InsecureSkipTLSVerify    bool   `json:"insecure-skip-tls-verify,omitempty"`
+	// Path to a certificate authority file on the machine of the user
+	CertificateAuthority  string `json:"certificate-authority,omitempty"`
+	InsecureSkipTLSVerify bool   `json:"insecure-skip-tls-verify,omitempty"`
+	// Server name checked in the certificate of the server, defaults to the host of the server URL
+	TLSServerName string `json:"tls-server-name,omitempty"`
+	// Proxy used for all requests to the cluster
+	ProxyURL           string           `json:"proxy-url,omitempty"`
+	DisableCompression bool             `json:"disable-compression,omitempty"`
+	Extensions         []NamedExtension `json:"extensions,omitempty"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // Context represents a context entry
 type Context struct {
 	Name    string      `json:"name"`
 	Context ContextSpec `json:"context"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // Context represents a user entry
 type User struct {
 	Name string   `json:"name"`
 	User UserSpec `json:"user"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // ContextSpec defines the details of a context
 type ContextSpec struct {
 	Cluster string `json:"cluster"`
 	User    string `json:"user"`
+	// Default namespace of the context
+	Namespace  string           `json:"namespace,omitempty"`
+	Extensions []NamedExtension `json:"extensions,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// NamedExtension holds an extension of a kubeconfig entry
+type NamedExtension struct {
+	Name      string               `json:"name"`
+	Extension runtime.RawExtension `json:"extension"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // UserSpec defines the details of a user
 type UserSpec struct {
-	AuthProvider AuthProviderSpec `json:"auth-provider"`
+	AuthProvider *AuthProviderSpec `json:"auth-provider,omitempty"`
+	Exec         *ExecConfig       `json:"exec,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ExecConfig defines a credential plugin, such as kubelogin
+type ExecConfig struct {
+	APIVersion      string   `json:"apiVersion"`
+	Command         string   `json:"command"`
+	Args            []string `json:"args,omitempty"`
+	InteractiveMode string   `json:"interactiveMode,omitempty"`
 }
 
 // AuthProviderSpec defines the authentication provider details
@@ -0,0 +1,153 @@
+package v1beta1
+
+import (
+	"encoding/json"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+)
+
+const (
+	// Annotation holding the credentials of a Kubeconfig stored as v1alpha1
+	CredentialsAnnotation = "kubebrowser.io/credentials"
+	// Annotation holding the v1alpha1 fields that have no v1beta1 equivalent
+	legacyDataAnnotation = "kubebrowser.io/v1alpha1-data"
+)
+
+// v1alpha1 fields dropped by v1beta1, kept in an annotation so conversions do not lose data
+type legacyData struct {
+	// Set when not v1, which may be empty
+	APIVersion *string `json:"apiVersion,omitempty"`
+	// Set when not Config, which may be empty
+	Kind  *string         `json:"kind,omitempty"`
+	Users []v1alpha1.User `json:"users,omitempty"`
+	// Extensions of the whole kubeconfig
+	Extensions []v1alpha1.NamedExtension `json:"extensions,omitempty"`
+}
+
+// ConvertFromV1alpha1 converts a v1alpha1 Kubeconfig to v1beta1
+func ConvertFromV1alpha1(in *v1alpha1.Kubeconfig, out *Kubeconfig) error {
+	in = in.DeepCopy()
+	out.TypeMeta.APIVersion = SchemeGroupVersion.String()
+	out.TypeMeta.Kind = "Kubeconfig"
+	out.ObjectMeta = in.ObjectMeta
+	out.Status = in.Status
+
+	out.Spec = KubeconfigSpec{
+		Display: Display{
+			Name:        in.Spec.Name,
+			Description: in.Spec.Description,
+			Environment: in.Spec.Environment,
+			Region:      in.Spec.Region,
+			Provider:    in.Spec.Provider,
+			Owner:       in.Spec.Owner,
+			Contact:     in.Spec.Contact,
+			Links:       in.Spec.Links,
+			Tags:        in.Spec.Tags,
+		},
+		Clusters:       in.Spec.Kubeconfig.Clusters,
+		Contexts:       in.Spec.Kubeconfig.Contexts,
+		CurrentContext: in.Spec.Kubeconfig.CurrentContext,
+		KubeconfigFrom: in.Spec.KubeconfigFrom,
+		Lifecycle:      in.Spec.Lifecycle,
+		Access: Access{
+			Whitelist:      in.Spec.Whitelist,
+			Approvers:      in.Spec.Approvers,
+			RequireAuthAge: in.Spec.RequireAuthAge,
+			RequiredACR:    in.Spec.RequiredACR,
+		},
+	}
+
+	if raw, ok := out.Annotations[CredentialsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &out.Spec.Credentials); err != nil {
+			return err
+		}
+		deleteAnnotation(&out.ObjectMeta.Annotations, CredentialsAnnotation)
+	}
+
+	legacy := legacyData{Users: in.Spec.Kubeconfig.Users, Extensions: in.Spec.Kubeconfig.Extensions}
+	if in.Spec.Kubeconfig.APIVersion != "v1" {
+		legacy.APIVersion = &in.Spec.Kubeconfig.APIVersion
+	}
+	if in.Spec.Kubeconfig.Kind != "Config" {
+		legacy.Kind = &in.Spec.Kubeconfig.Kind
+	}
+	if legacy.APIVersion != nil || legacy.Kind != nil || len(legacy.Users) > 0 || len(legacy.Extensions) > 0 {
+		return setAnnotation(&out.ObjectMeta.Annotations, legacyDataAnnotation, legacy)
+	}
+	return nil
+}
+
+// ConvertToV1alpha1 converts a v1beta1 Kubeconfig to v1alpha1
+func ConvertToV1alpha1(in *Kubeconfig, out *v1alpha1.Kubeconfig) error {
+	in = in.DeepCopy()
+	out.TypeMeta.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	out.TypeMeta.Kind = "Kubeconfig"
+	out.ObjectMeta = in.ObjectMeta
+	out.Status = in.Status
+
+	var legacy legacyData
+	if raw, ok := out.Annotations[legacyDataAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &legacy); err != nil {
+			return err
+		}
+		deleteAnnotation(&out.ObjectMeta.Annotations, legacyDataAnnotation)
+	}
+	apiVersion, kind := "v1", "Config"
+	if legacy.APIVersion != nil {
+		apiVersion = *legacy.APIVersion
+	}
+	if legacy.Kind != nil {
+		kind = *legacy.Kind
+	}
+
+	out.Spec = v1alpha1.KubeconfigSpec{
+		Name:        in.Spec.Display.Name,
+		Description: in.Spec.Display.Description,
+		Environment: in.Spec.Display.Environment,
+		Region:      in.Spec.Display.Region,
+		Provider:    in.Spec.Display.Provider,
+		Owner:       in.Spec.Display.Owner,
+		Contact:     in.Spec.Display.Contact,
+		Links:       in.Spec.Display.Links,
+		Tags:        in.Spec.Display.Tags,
+		Kubeconfig: v1alpha1.KubeconfigData{
+			APIVersion:     apiVersion,
+			Kind:           kind,
+			Clusters:       in.Spec.Clusters,
+			Contexts:       in.Spec.Contexts,
+			CurrentContext: in.Spec.CurrentContext,
+			Users:          legacy.Users,
+			Extensions:     legacy.Extensions,
+		},
+		KubeconfigFrom: in.Spec.KubeconfigFrom,
+		Lifecycle:      in.Spec.Lifecycle,
+		Whitelist:      in.Spec.Access.Whitelist,
+		Approvers:      in.Spec.Access.Approvers,
+		RequireAuthAge: in.Spec.Access.RequireAuthAge,
+		RequiredACR:    in.Spec.Access.RequiredACR,
+	}
+
+	if in.Spec.Credentials != (Credentials{}) {
+		return setAnnotation(&out.ObjectMeta.Annotations, CredentialsAnnotation, in.Spec.Credentials)
+	}
+	return nil
+}
+
+func setAnnotation(annotations *map[string]string, key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if *annotations == nil {
+		*annotations = make(map[string]string)
+	}
+	(*annotations)[key] = string(raw)
+	return nil
+}
+
+func deleteAnnotation(annotations *map[string]string, key string) {
+	delete(*annotations, key)
+	if len(*annotations) == 0 {
+		*annotations = nil
+	}
+}
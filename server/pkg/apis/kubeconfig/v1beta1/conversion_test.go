@@ -0,0 +1,232 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	v1alpha1TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Kubeconfig"}
+	v1beta1TypeMeta  = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "Kubeconfig"}
+)
+
+func testClusters() []v1alpha1.Cluster {
+	return []v1alpha1.Cluster{{
+		Name: "prod",
+		Cluster: v1alpha1.Details{
+			Server:        "https://prod.example.com:6443",
+			TLSServerName: "prod.internal",
+			Extensions:    []v1alpha1.NamedExtension{{Name: "vendor", Extension: runtime.RawExtension{Raw: []byte(`{"zone":"a"}`)}}},
+		},
+	}}
+}
+
+func testContexts() []v1alpha1.Context {
+	return []v1alpha1.Context{{Name: "prod", Context: v1alpha1.ContextSpec{Cluster: "prod", User: "oidc", Namespace: "default"}}}
+}
+
+func TestV1alpha1RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *v1alpha1.Kubeconfig
+	}{
+		{
+			name: "empty spec",
+			in:   &v1alpha1.Kubeconfig{TypeMeta: v1alpha1TypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+		},
+		{
+			name: "minimal kubeconfig",
+			in: &v1alpha1.Kubeconfig{
+				TypeMeta:   v1alpha1TypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "kubebrowser"},
+				Spec: v1alpha1.KubeconfigSpec{
+					Name: "Production",
+					Kubeconfig: v1alpha1.KubeconfigData{
+						APIVersion: "v1",
+						Kind:       "Config",
+						Clusters:   testClusters(),
+						Contexts:   testContexts(),
+					},
+				},
+			},
+		},
+		{
+			name: "every field",
+			in: &v1alpha1.Kubeconfig{
+				TypeMeta: v1alpha1TypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "prod",
+					Namespace:   "kubebrowser",
+					Labels:      map[string]string{"env": "prod"},
+					Annotations: map[string]string{"owner": "platform"},
+				},
+				Spec: v1alpha1.KubeconfigSpec{
+					Name:        "Production",
+					Description: "Main production cluster",
+					Environment: "prod",
+					Region:      "eu-west-1",
+					Provider:    "aws",
+					Owner:       "platform",
+					Contact:     "#platform",
+					Links:       []v1alpha1.Link{{Name: "Runbook", URL: "https://wiki.example.com/prod"}},
+					Tags:        []string{"critical"},
+					Lifecycle:   &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDeprecated, Replacement: "prod-2"},
+					Kubeconfig: v1alpha1.KubeconfigData{
+						APIVersion:     "v1",
+						Kind:           "Config",
+						Clusters:       testClusters(),
+						Contexts:       testContexts(),
+						CurrentContext: "prod",
+					},
+					Whitelist:      &v1alpha1.Whitelist{Groups: []string{"platform"}},
+					Approvers:      &v1alpha1.Whitelist{Users: []string{"lead@example.com"}},
+					RequireAuthAge: &metav1.Duration{Duration: time.Hour},
+					RequiredACR:    "mfa",
+				},
+				Status: v1alpha1.KubeconfigStatus{ObservedGeneration: 3},
+			},
+		},
+		{
+			name: "credentials annotation",
+			in: &v1alpha1.Kubeconfig{
+				TypeMeta: v1alpha1TypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "prod",
+					Annotations: map[string]string{CredentialsAnnotation: `{"mode":"Exec"}`},
+				},
+				Spec: v1alpha1.KubeconfigSpec{Name: "Production"},
+			},
+		},
+		{
+			name: "fields without v1beta1 equivalent",
+			in: &v1alpha1.Kubeconfig{
+				TypeMeta:   v1alpha1TypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+				Spec: v1alpha1.KubeconfigSpec{
+					Name: "Legacy",
+					Kubeconfig: v1alpha1.KubeconfigData{
+						APIVersion: "v2",
+						Kind:       "Custom",
+						Clusters:   testClusters(),
+						Contexts:   testContexts(),
+						Users:      []v1alpha1.User{{Name: "oidc", User: v1alpha1.UserSpec{Exec: &v1alpha1.ExecConfig{APIVersion: "client.authentication.k8s.io/v1beta1", Command: "kubectl"}}}},
+						Extensions: []v1alpha1.NamedExtension{{Name: "vendor", Extension: runtime.RawExtension{Raw: []byte(`{"team":"platform"}`)}}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var beta Kubeconfig
+			if err := ConvertFromV1alpha1(tt.in, &beta); err != nil {
+				t.Fatalf("ConvertFromV1alpha1() error = %v", err)
+			}
+			if beta.TypeMeta != v1beta1TypeMeta {
+				t.Errorf("ConvertFromV1alpha1() TypeMeta = %v", beta.TypeMeta)
+			}
+			if _, ok := beta.Annotations[CredentialsAnnotation]; ok {
+				t.Errorf("ConvertFromV1alpha1() kept the credentials annotation")
+			}
+
+			var out v1alpha1.Kubeconfig
+			if err := ConvertToV1alpha1(&beta, &out); err != nil {
+				t.Fatalf("ConvertToV1alpha1() error = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(tt.in, &out) {
+				t.Errorf("round trip = %+v, want %+v", out, *tt.in)
+			}
+		})
+	}
+}
+
+func TestV1beta1RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *Kubeconfig
+	}{
+		{
+			name: "empty spec",
+			in:   &Kubeconfig{TypeMeta: v1beta1TypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+		},
+		{
+			name: "every field",
+			in: &Kubeconfig{
+				TypeMeta: v1beta1TypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "prod",
+					Namespace:   "kubebrowser",
+					Annotations: map[string]string{"owner": "platform"},
+				},
+				Spec: KubeconfigSpec{
+					Display: Display{
+						Name:        "Production",
+						Description: "Main production cluster",
+						Environment: "prod",
+						Links:       []v1alpha1.Link{{Name: "Runbook", URL: "https://wiki.example.com/prod"}},
+						Tags:        []string{"critical"},
+					},
+					Clusters:       testClusters(),
+					Contexts:       testContexts(),
+					CurrentContext: "prod",
+					Lifecycle:      &v1alpha1.Lifecycle{State: v1alpha1.LifecycleMaintenance, Message: "Upgrading"},
+					Access: Access{
+						Whitelist:      &v1alpha1.Whitelist{Groups: []string{"platform"}},
+						Approvers:      &v1alpha1.Whitelist{Users: []string{"lead@example.com"}},
+						RequireAuthAge: &metav1.Duration{Duration: time.Hour},
+						RequiredACR:    "mfa",
+					},
+					Credentials: Credentials{Mode: CredentialModeExec},
+				},
+			},
+		},
+		{
+			name: "sourced clusters",
+			in: &Kubeconfig{
+				TypeMeta:   v1beta1TypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "sourced"},
+				Spec: KubeconfigSpec{
+					Display:        Display{Name: "Sourced"},
+					KubeconfigFrom: &v1alpha1.KubeconfigSource{SecretRef: &v1alpha1.KeyReference{Name: "prod-kubeconfig"}},
+					Credentials:    Credentials{Mode: CredentialModeAuthProvider},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var alpha v1alpha1.Kubeconfig
+			if err := ConvertToV1alpha1(tt.in, &alpha); err != nil {
+				t.Fatalf("ConvertToV1alpha1() error = %v", err)
+			}
+			if alpha.TypeMeta != v1alpha1TypeMeta {
+				t.Errorf("ConvertToV1alpha1() TypeMeta = %v", alpha.TypeMeta)
+			}
+			if alpha.Spec.Kubeconfig.APIVersion != "v1" || alpha.Spec.Kubeconfig.Kind != "Config" {
+				t.Errorf("ConvertToV1alpha1() kubeconfig type = %s %s, want v1 Config", alpha.Spec.Kubeconfig.APIVersion, alpha.Spec.Kubeconfig.Kind)
+			}
+
+			var out Kubeconfig
+			if err := ConvertFromV1alpha1(&alpha, &out); err != nil {
+				t.Fatalf("ConvertFromV1alpha1() error = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(tt.in, &out) {
+				t.Errorf("round trip = %+v, want %+v", out, *tt.in)
+			}
+		})
+	}
+}
+
+func TestConvertToV1alpha1InvalidAnnotation(t *testing.T) {
+	in := &Kubeconfig{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{legacyDataAnnotation: "{"}}}
+	if err := ConvertToV1alpha1(in, &v1alpha1.Kubeconfig{}); err == nil {
+		t.Error("ConvertToV1alpha1() error = nil, want an error")
+	}
+}
@@ -0,0 +1,101 @@
+package v1beta1
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KubeconfigList contains a list of Kubeconfig objects
+type KubeconfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Kubeconfig `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Kubeconfig is the Schema for the kubeconfigs API
+type Kubeconfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              KubeconfigSpec            `json:"spec,omitempty"`
+	Status            v1alpha1.KubeconfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// KubeconfigSpec defines the desired state of Kubeconfig
+type KubeconfigSpec struct {
+	Display        Display            `json:"display"`
+	Clusters       []v1alpha1.Cluster `json:"clusters,omitempty"`
+	Contexts       []v1alpha1.Context `json:"contexts,omitempty"`
+	CurrentContext string             `json:"currentContext,omitempty"`
+	// Secret or ConfigMap holding the kubeconfig or the CA bundle of the clusters
+	KubeconfigFrom *v1alpha1.KubeconfigSource `json:"kubeconfigFrom,omitempty"`
+	// Lifecycle of the cluster, active if unset
+	Lifecycle   *v1alpha1.Lifecycle `json:"lifecycle,omitempty"`
+	Access      Access              `json:"access,omitempty"`
+	Credentials Credentials         `json:"credentials,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Display defines how the Kubeconfig is presented in the catalog
+type Display struct {
+	Name string `json:"name"`
+	// Description of the cluster in the catalog
+	Description string `json:"description,omitempty"`
+	// Environment of the cluster, such as prod, staging or dev
+	Environment string `json:"environment,omitempty"`
+	Region      string `json:"region,omitempty"`
+	// Cloud provider hosting the cluster, such as aws or on-premise
+	Provider string `json:"provider,omitempty"`
+	// Team owning the cluster
+	Owner string `json:"owner,omitempty"`
+	// How to reach the owners, such as an email address or a chat channel
+	Contact string `json:"contact,omitempty"`
+	// Links to the runbook, the dashboards or any other page about the cluster
+	Links []v1alpha1.Link `json:"links,omitempty"`
+	// Free-form tags, usable to filter the catalog
+	Tags []string `json:"tags,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Access defines who can get the Kubeconfig and under which conditions
+type Access struct {
+	// Users and groups allowed to get the Kubeconfig, everyone if unset
+	Whitelist *v1alpha1.Whitelist `json:"whitelist,omitempty"`
+	// Users and groups allowed to approve AccessRequests on this Kubeconfig
+	Approvers *v1alpha1.Whitelist `json:"approvers,omitempty"`
+	// Maximum age of the user authentication to render this Kubeconfig
+	RequireAuthAge *metav1.Duration `json:"requireAuthAge,omitempty"`
+	// Authentication context class reference the user must have logged in with
+	RequiredACR string `json:"requiredACR,omitempty"`
+}
+
+type CredentialMode string
+
+const (
+	// Renders the ID and refresh tokens with the oidc auth-provider of kubectl
+	CredentialModeAuthProvider CredentialMode = "AuthProvider"
+	// Renders an exec credential plugin calling kubelogin, no token is handed out
+	CredentialModeExec CredentialMode = "Exec"
+)
+
+// Credentials defines how the user credentials are rendered in the Kubeconfig
+type Credentials struct {
+	// Defaults to AuthProvider
+	Mode CredentialMode `json:"mode,omitempty"`
+}
+
+// Resource returns the GroupResource for the Kubeconfig resource.
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
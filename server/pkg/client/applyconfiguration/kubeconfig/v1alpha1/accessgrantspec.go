@@ -0,0 +1,88 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessGrantSpecApplyConfiguration represents a declarative configuration of the AccessGrantSpec type for use
+// with apply.
+type AccessGrantSpecApplyConfiguration struct {
+	Kubeconfig *string  `json:"kubeconfig,omitempty"`
+	User       *string  `json:"user,omitempty"`
+	ExpiresAt  *v1.Time `json:"expiresAt,omitempty"`
+	Reason     *string  `json:"reason,omitempty"`
+	BreakGlass *bool    `json:"breakGlass,omitempty"`
+	TicketID   *string  `json:"ticketID,omitempty"`
+}
+
+// AccessGrantSpecApplyConfiguration constructs a declarative configuration of the AccessGrantSpec type for use with
+// apply.
+func AccessGrantSpec() *AccessGrantSpecApplyConfiguration {
+	return &AccessGrantSpecApplyConfiguration{}
+}
+
+// WithKubeconfig sets the Kubeconfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kubeconfig field is set to the value of the last call.
+func (b *AccessGrantSpecApplyConfiguration) WithKubeconfig(value string) *AccessGrantSpecApplyConfiguration {
+	b.Kubeconfig = &value
+	return b
+}
+
+// WithUser sets the User field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the User field is set to the value of the last call.
+func (b *AccessGrantSpecApplyConfiguration) WithUser(value string) *AccessGrantSpecApplyConfiguration {
+	b.User = &value
+	return b
+}
+
+// WithExpiresAt sets the ExpiresAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiresAt field is set to the value of the last call.
+func (b *AccessGrantSpecApplyConfiguration) WithExpiresAt(value v1.Time) *AccessGrantSpecApplyConfiguration {
+	b.ExpiresAt = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *AccessGrantSpecApplyConfiguration) WithReason(value string) *AccessGrantSpecApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithBreakGlass sets the BreakGlass field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BreakGlass field is set to the value of the last call.
+func (b *AccessGrantSpecApplyConfiguration) WithBreakGlass(value bool) *AccessGrantSpecApplyConfiguration {
+	b.BreakGlass = &value
+	return b
+}
+
+// WithTicketID sets the TicketID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TicketID field is set to the value of the last call.
+func (b *AccessGrantSpecApplyConfiguration) WithTicketID(value string) *AccessGrantSpecApplyConfiguration {
+	b.TicketID = &value
+	return b
+}
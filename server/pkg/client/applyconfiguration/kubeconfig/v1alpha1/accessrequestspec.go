@@ -0,0 +1,70 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessRequestSpecApplyConfiguration represents a declarative configuration of the AccessRequestSpec type for use
+// with apply.
+type AccessRequestSpecApplyConfiguration struct {
+	Kubeconfig *string      `json:"kubeconfig,omitempty"`
+	Requester  *string      `json:"requester,omitempty"`
+	Reason     *string      `json:"reason,omitempty"`
+	Duration   *v1.Duration `json:"duration,omitempty"`
+}
+
+// AccessRequestSpecApplyConfiguration constructs a declarative configuration of the AccessRequestSpec type for use with
+// apply.
+func AccessRequestSpec() *AccessRequestSpecApplyConfiguration {
+	return &AccessRequestSpecApplyConfiguration{}
+}
+
+// WithKubeconfig sets the Kubeconfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kubeconfig field is set to the value of the last call.
+func (b *AccessRequestSpecApplyConfiguration) WithKubeconfig(value string) *AccessRequestSpecApplyConfiguration {
+	b.Kubeconfig = &value
+	return b
+}
+
+// WithRequester sets the Requester field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Requester field is set to the value of the last call.
+func (b *AccessRequestSpecApplyConfiguration) WithRequester(value string) *AccessRequestSpecApplyConfiguration {
+	b.Requester = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *AccessRequestSpecApplyConfiguration) WithReason(value string) *AccessRequestSpecApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *AccessRequestSpecApplyConfiguration) WithDuration(value v1.Duration) *AccessRequestSpecApplyConfiguration {
+	b.Duration = &value
+	return b
+}
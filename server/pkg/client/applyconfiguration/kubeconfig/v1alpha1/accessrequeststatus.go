@@ -0,0 +1,80 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessRequestStatusApplyConfiguration represents a declarative configuration of the AccessRequestStatus type for use
+// with apply.
+type AccessRequestStatusApplyConfiguration struct {
+	Phase     *kubeconfigv1alpha1.AccessRequestPhase `json:"phase,omitempty"`
+	DecidedBy *string                                `json:"decidedBy,omitempty"`
+	DecidedAt *v1.Time                               `json:"decidedAt,omitempty"`
+	ExpiresAt *v1.Time                               `json:"expiresAt,omitempty"`
+	Message   *string                                `json:"message,omitempty"`
+}
+
+// AccessRequestStatusApplyConfiguration constructs a declarative configuration of the AccessRequestStatus type for use with
+// apply.
+func AccessRequestStatus() *AccessRequestStatusApplyConfiguration {
+	return &AccessRequestStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *AccessRequestStatusApplyConfiguration) WithPhase(value kubeconfigv1alpha1.AccessRequestPhase) *AccessRequestStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithDecidedBy sets the DecidedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DecidedBy field is set to the value of the last call.
+func (b *AccessRequestStatusApplyConfiguration) WithDecidedBy(value string) *AccessRequestStatusApplyConfiguration {
+	b.DecidedBy = &value
+	return b
+}
+
+// WithDecidedAt sets the DecidedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DecidedAt field is set to the value of the last call.
+func (b *AccessRequestStatusApplyConfiguration) WithDecidedAt(value v1.Time) *AccessRequestStatusApplyConfiguration {
+	b.DecidedAt = &value
+	return b
+}
+
+// WithExpiresAt sets the ExpiresAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiresAt field is set to the value of the last call.
+func (b *AccessRequestStatusApplyConfiguration) WithExpiresAt(value v1.Time) *AccessRequestStatusApplyConfiguration {
+	b.ExpiresAt = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *AccessRequestStatusApplyConfiguration) WithMessage(value string) *AccessRequestStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
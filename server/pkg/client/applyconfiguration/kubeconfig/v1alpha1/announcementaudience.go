@@ -0,0 +1,65 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// AnnouncementAudienceApplyConfiguration represents a declarative configuration of the AnnouncementAudience type for use
+// with apply.
+type AnnouncementAudienceApplyConfiguration struct {
+	Users              []string                            `json:"users,omitempty"`
+	Groups             []string                            `json:"groups,omitempty"`
+	KubeconfigSelector *v1.LabelSelectorApplyConfiguration `json:"kubeconfigSelector,omitempty"`
+}
+
+// AnnouncementAudienceApplyConfiguration constructs a declarative configuration of the AnnouncementAudience type for use with
+// apply.
+func AnnouncementAudience() *AnnouncementAudienceApplyConfiguration {
+	return &AnnouncementAudienceApplyConfiguration{}
+}
+
+// WithUsers adds the given value to the Users field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Users field.
+func (b *AnnouncementAudienceApplyConfiguration) WithUsers(values ...string) *AnnouncementAudienceApplyConfiguration {
+	for i := range values {
+		b.Users = append(b.Users, values[i])
+	}
+	return b
+}
+
+// WithGroups adds the given value to the Groups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Groups field.
+func (b *AnnouncementAudienceApplyConfiguration) WithGroups(values ...string) *AnnouncementAudienceApplyConfiguration {
+	for i := range values {
+		b.Groups = append(b.Groups, values[i])
+	}
+	return b
+}
+
+// WithKubeconfigSelector sets the KubeconfigSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubeconfigSelector field is set to the value of the last call.
+func (b *AnnouncementAudienceApplyConfiguration) WithKubeconfigSelector(value *v1.LabelSelectorApplyConfiguration) *AnnouncementAudienceApplyConfiguration {
+	b.KubeconfigSelector = value
+	return b
+}
@@ -0,0 +1,80 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnouncementSpecApplyConfiguration represents a declarative configuration of the AnnouncementSpec type for use
+// with apply.
+type AnnouncementSpecApplyConfiguration struct {
+	Severity  *kubeconfigv1alpha1.AnnouncementSeverity `json:"severity,omitempty"`
+	Body      *string                                  `json:"body,omitempty"`
+	StartTime *v1.Time                                 `json:"startTime,omitempty"`
+	EndTime   *v1.Time                                 `json:"endTime,omitempty"`
+	Audience  *AnnouncementAudienceApplyConfiguration  `json:"audience,omitempty"`
+}
+
+// AnnouncementSpecApplyConfiguration constructs a declarative configuration of the AnnouncementSpec type for use with
+// apply.
+func AnnouncementSpec() *AnnouncementSpecApplyConfiguration {
+	return &AnnouncementSpecApplyConfiguration{}
+}
+
+// WithSeverity sets the Severity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Severity field is set to the value of the last call.
+func (b *AnnouncementSpecApplyConfiguration) WithSeverity(value kubeconfigv1alpha1.AnnouncementSeverity) *AnnouncementSpecApplyConfiguration {
+	b.Severity = &value
+	return b
+}
+
+// WithBody sets the Body field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Body field is set to the value of the last call.
+func (b *AnnouncementSpecApplyConfiguration) WithBody(value string) *AnnouncementSpecApplyConfiguration {
+	b.Body = &value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *AnnouncementSpecApplyConfiguration) WithStartTime(value v1.Time) *AnnouncementSpecApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithEndTime sets the EndTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndTime field is set to the value of the last call.
+func (b *AnnouncementSpecApplyConfiguration) WithEndTime(value v1.Time) *AnnouncementSpecApplyConfiguration {
+	b.EndTime = &value
+	return b
+}
+
+// WithAudience sets the Audience field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Audience field is set to the value of the last call.
+func (b *AnnouncementSpecApplyConfiguration) WithAudience(value *AnnouncementAudienceApplyConfiguration) *AnnouncementSpecApplyConfiguration {
+	b.Audience = value
+	return b
+}
@@ -0,0 +1,93 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterStatusApplyConfiguration represents a declarative configuration of the ClusterStatus type for use
+// with apply.
+type ClusterStatusApplyConfiguration struct {
+	Name                      *string `json:"name,omitempty"`
+	Reachable                 *bool   `json:"reachable,omitempty"`
+	Ready                     *bool   `json:"ready,omitempty"`
+	Version                   *string `json:"version,omitempty"`
+	CertificateValid          *bool   `json:"certificateValid,omitempty"`
+	CertificateAuthorityValid *bool   `json:"certificateAuthorityValid,omitempty"`
+	Message                   *string `json:"message,omitempty"`
+}
+
+// ClusterStatusApplyConfiguration constructs a declarative configuration of the ClusterStatus type for use with
+// apply.
+func ClusterStatus() *ClusterStatusApplyConfiguration {
+	return &ClusterStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithName(value string) *ClusterStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithReachable sets the Reachable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reachable field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithReachable(value bool) *ClusterStatusApplyConfiguration {
+	b.Reachable = &value
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithReady(value bool) *ClusterStatusApplyConfiguration {
+	b.Ready = &value
+	return b
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithVersion(value string) *ClusterStatusApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
+// WithCertificateValid sets the CertificateValid field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertificateValid field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithCertificateValid(value bool) *ClusterStatusApplyConfiguration {
+	b.CertificateValid = &value
+	return b
+}
+
+// WithCertificateAuthorityValid sets the CertificateAuthorityValid field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertificateAuthorityValid field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithCertificateAuthorityValid(value bool) *ClusterStatusApplyConfiguration {
+	b.CertificateAuthorityValid = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterStatusApplyConfiguration) WithMessage(value string) *ClusterStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
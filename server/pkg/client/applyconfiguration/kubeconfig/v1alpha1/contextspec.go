@@ -21,8 +21,10 @@ package v1alpha1
 // ContextSpecApplyConfiguration represents a declarative configuration of the ContextSpec type for use
 // with apply.
 type ContextSpecApplyConfiguration struct {
-	Cluster *string `json:"cluster,omitempty"`
-	User    *string `json:"user,omitempty"`
+	Cluster    *string                            `json:"cluster,omitempty"`
+	User       *string                            `json:"user,omitempty"`
+	Namespace  *string                            `json:"namespace,omitempty"`
+	Extensions []NamedExtensionApplyConfiguration `json:"extensions,omitempty"`
 }
 
 // ContextSpecApplyConfiguration constructs a declarative configuration of the ContextSpec type for use with
@@ -46,3 +48,24 @@ func (b *ContextSpecApplyConfiguration) WithUser(value string) *ContextSpecApply
 	b.User = &value
 	return b
 }
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ContextSpecApplyConfiguration) WithNamespace(value string) *ContextSpecApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithExtensions adds the given value to the Extensions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Extensions field.
+func (b *ContextSpecApplyConfiguration) WithExtensions(values ...*NamedExtensionApplyConfiguration) *ContextSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExtensions")
+		}
+		b.Extensions = append(b.Extensions, *values[i])
+	}
+	return b
+}
@@ -21,9 +21,14 @@ package v1alpha1
 // DetailsApplyConfiguration represents a declarative configuration of the Details type for use
 // with apply.
 type DetailsApplyConfiguration struct {
-	Server                   *string `json:"server,omitempty"`
-	CertificateAuthorityData *string `json:"certificate-authority-data,omitempty"`
-	InsecureSkipTLSVerify    *bool   `json:"insecure-skip-tls-verify,omitempty"`
+	Server                   *string                            `json:"server,omitempty"`
+	CertificateAuthorityData *string                            `json:"certificate-authority-data,omitempty"`
+	CertificateAuthority     *string                            `json:"certificate-authority,omitempty"`
+	InsecureSkipTLSVerify    *bool                              `json:"insecure-skip-tls-verify,omitempty"`
+	TLSServerName            *string                            `json:"tls-server-name,omitempty"`
+	ProxyURL                 *string                            `json:"proxy-url,omitempty"`
+	DisableCompression       *bool                              `json:"disable-compression,omitempty"`
+	Extensions               []NamedExtensionApplyConfiguration `json:"extensions,omitempty"`
 }
 
 // DetailsApplyConfiguration constructs a declarative configuration of the Details type for use with
@@ -48,6 +53,14 @@ func (b *DetailsApplyConfiguration) WithCertificateAuthorityData(value string) *
 	return b
 }
 
+// WithCertificateAuthority sets the CertificateAuthority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertificateAuthority field is set to the value of the last call.
+func (b *DetailsApplyConfiguration) WithCertificateAuthority(value string) *DetailsApplyConfiguration {
+	b.CertificateAuthority = &value
+	return b
+}
+
 // WithInsecureSkipTLSVerify sets the InsecureSkipTLSVerify field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the InsecureSkipTLSVerify field is set to the value of the last call.
@@ -55,3 +68,40 @@ func (b *DetailsApplyConfiguration) WithInsecureSkipTLSVerify(value bool) *Detai
 	b.InsecureSkipTLSVerify = &value
 	return b
 }
+
+// WithTLSServerName sets the TLSServerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSServerName field is set to the value of the last call.
+func (b *DetailsApplyConfiguration) WithTLSServerName(value string) *DetailsApplyConfiguration {
+	b.TLSServerName = &value
+	return b
+}
+
+// WithProxyURL sets the ProxyURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProxyURL field is set to the value of the last call.
+func (b *DetailsApplyConfiguration) WithProxyURL(value string) *DetailsApplyConfiguration {
+	b.ProxyURL = &value
+	return b
+}
+
+// WithDisableCompression sets the DisableCompression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableCompression field is set to the value of the last call.
+func (b *DetailsApplyConfiguration) WithDisableCompression(value bool) *DetailsApplyConfiguration {
+	b.DisableCompression = &value
+	return b
+}
+
+// WithExtensions adds the given value to the Extensions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Extensions field.
+func (b *DetailsApplyConfiguration) WithExtensions(values ...*NamedExtensionApplyConfiguration) *DetailsApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExtensions")
+		}
+		b.Extensions = append(b.Extensions, *values[i])
+	}
+	return b
+}
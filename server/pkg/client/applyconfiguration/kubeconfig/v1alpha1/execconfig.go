@@ -0,0 +1,68 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ExecConfigApplyConfiguration represents a declarative configuration of the ExecConfig type for use
+// with apply.
+type ExecConfigApplyConfiguration struct {
+	APIVersion      *string  `json:"apiVersion,omitempty"`
+	Command         *string  `json:"command,omitempty"`
+	Args            []string `json:"args,omitempty"`
+	InteractiveMode *string  `json:"interactiveMode,omitempty"`
+}
+
+// ExecConfigApplyConfiguration constructs a declarative configuration of the ExecConfig type for use with
+// apply.
+func ExecConfig() *ExecConfigApplyConfiguration {
+	return &ExecConfigApplyConfiguration{}
+}
+
+// WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIVersion field is set to the value of the last call.
+func (b *ExecConfigApplyConfiguration) WithAPIVersion(value string) *ExecConfigApplyConfiguration {
+	b.APIVersion = &value
+	return b
+}
+
+// WithCommand sets the Command field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Command field is set to the value of the last call.
+func (b *ExecConfigApplyConfiguration) WithCommand(value string) *ExecConfigApplyConfiguration {
+	b.Command = &value
+	return b
+}
+
+// WithArgs adds the given value to the Args field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Args field.
+func (b *ExecConfigApplyConfiguration) WithArgs(values ...string) *ExecConfigApplyConfiguration {
+	for i := range values {
+		b.Args = append(b.Args, values[i])
+	}
+	return b
+}
+
+// WithInteractiveMode sets the InteractiveMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InteractiveMode field is set to the value of the last call.
+func (b *ExecConfigApplyConfiguration) WithInteractiveMode(value string) *ExecConfigApplyConfiguration {
+	b.InteractiveMode = &value
+	return b
+}
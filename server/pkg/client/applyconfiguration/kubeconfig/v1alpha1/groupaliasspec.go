@@ -0,0 +1,48 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// GroupAliasSpecApplyConfiguration represents a declarative configuration of the GroupAliasSpec type for use
+// with apply.
+type GroupAliasSpecApplyConfiguration struct {
+	ID          *string `json:"id,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+// GroupAliasSpecApplyConfiguration constructs a declarative configuration of the GroupAliasSpec type for use with
+// apply.
+func GroupAliasSpec() *GroupAliasSpecApplyConfiguration {
+	return &GroupAliasSpecApplyConfiguration{}
+}
+
+// WithID sets the ID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ID field is set to the value of the last call.
+func (b *GroupAliasSpecApplyConfiguration) WithID(value string) *GroupAliasSpecApplyConfiguration {
+	b.ID = &value
+	return b
+}
+
+// WithDisplayName sets the DisplayName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisplayName field is set to the value of the last call.
+func (b *GroupAliasSpecApplyConfiguration) WithDisplayName(value string) *GroupAliasSpecApplyConfiguration {
+	b.DisplayName = &value
+	return b
+}
@@ -29,7 +29,8 @@ import (
 type KubeconfigApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *KubeconfigSpecApplyConfiguration `json:"spec,omitempty"`
+	Spec                             *KubeconfigSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *KubeconfigStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // Kubeconfig constructs a declarative configuration of the Kubeconfig type for use with
@@ -209,6 +210,14 @@ func (b *KubeconfigApplyConfiguration) WithSpec(value *KubeconfigSpecApplyConfig
 	return b
 }
 
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *KubeconfigApplyConfiguration) WithStatus(value *KubeconfigStatusApplyConfiguration) *KubeconfigApplyConfiguration {
+	b.Status = value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *KubeconfigApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
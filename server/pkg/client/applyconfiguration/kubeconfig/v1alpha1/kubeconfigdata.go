@@ -21,12 +21,13 @@ package v1alpha1
 // KubeconfigDataApplyConfiguration represents a declarative configuration of the KubeconfigData type for use
 // with apply.
 type KubeconfigDataApplyConfiguration struct {
-	APIVersion     *string                     `json:"apiVersion,omitempty"`
-	Kind           *string                     `json:"kind,omitempty"`
-	Clusters       []ClusterApplyConfiguration `json:"clusters,omitempty"`
-	Contexts       []ContextApplyConfiguration `json:"contexts,omitempty"`
-	CurrentContext *string                     `json:"current-context,omitempty"`
-	Users          []UserApplyConfiguration    `json:"users,omitempty"`
+	APIVersion     *string                            `json:"apiVersion,omitempty"`
+	Kind           *string                            `json:"kind,omitempty"`
+	Clusters       []ClusterApplyConfiguration        `json:"clusters,omitempty"`
+	Contexts       []ContextApplyConfiguration        `json:"contexts,omitempty"`
+	CurrentContext *string                            `json:"current-context,omitempty"`
+	Users          []UserApplyConfiguration           `json:"users,omitempty"`
+	Extensions     []NamedExtensionApplyConfiguration `json:"extensions,omitempty"`
 }
 
 // KubeconfigDataApplyConfiguration constructs a declarative configuration of the KubeconfigData type for use with
@@ -97,3 +98,16 @@ func (b *KubeconfigDataApplyConfiguration) WithUsers(values ...*UserApplyConfigu
 	}
 	return b
 }
+
+// WithExtensions adds the given value to the Extensions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Extensions field.
+func (b *KubeconfigDataApplyConfiguration) WithExtensions(values ...*NamedExtensionApplyConfiguration) *KubeconfigDataApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExtensions")
+		}
+		b.Extensions = append(b.Extensions, *values[i])
+	}
+	return b
+}
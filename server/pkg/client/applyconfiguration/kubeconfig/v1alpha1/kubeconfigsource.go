@@ -0,0 +1,48 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// KubeconfigSourceApplyConfiguration represents a declarative configuration of the KubeconfigSource type for use
+// with apply.
+type KubeconfigSourceApplyConfiguration struct {
+	SecretRef    *KeyReferenceApplyConfiguration `json:"secretRef,omitempty"`
+	ConfigMapRef *KeyReferenceApplyConfiguration `json:"configMapRef,omitempty"`
+}
+
+// KubeconfigSourceApplyConfiguration constructs a declarative configuration of the KubeconfigSource type for use with
+// apply.
+func KubeconfigSource() *KubeconfigSourceApplyConfiguration {
+	return &KubeconfigSourceApplyConfiguration{}
+}
+
+// WithSecretRef sets the SecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretRef field is set to the value of the last call.
+func (b *KubeconfigSourceApplyConfiguration) WithSecretRef(value *KeyReferenceApplyConfiguration) *KubeconfigSourceApplyConfiguration {
+	b.SecretRef = value
+	return b
+}
+
+// WithConfigMapRef sets the ConfigMapRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapRef field is set to the value of the last call.
+func (b *KubeconfigSourceApplyConfiguration) WithConfigMapRef(value *KeyReferenceApplyConfiguration) *KubeconfigSourceApplyConfiguration {
+	b.ConfigMapRef = value
+	return b
+}
@@ -18,12 +18,29 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // KubeconfigSpecApplyConfiguration represents a declarative configuration of the KubeconfigSpec type for use
 // with apply.
 type KubeconfigSpecApplyConfiguration struct {
-	Name       *string                           `json:"name,omitempty"`
-	Kubeconfig *KubeconfigDataApplyConfiguration `json:"kubeconfig,omitempty"`
-	Whitelist  *WhitelistApplyConfiguration      `json:"whitelist,omitempty"`
+	Name           *string                             `json:"name,omitempty"`
+	Description    *string                             `json:"description,omitempty"`
+	Environment    *string                             `json:"environment,omitempty"`
+	Region         *string                             `json:"region,omitempty"`
+	Provider       *string                             `json:"provider,omitempty"`
+	Owner          *string                             `json:"owner,omitempty"`
+	Contact        *string                             `json:"contact,omitempty"`
+	Links          []LinkApplyConfiguration            `json:"links,omitempty"`
+	Tags           []string                            `json:"tags,omitempty"`
+	Lifecycle      *LifecycleApplyConfiguration        `json:"lifecycle,omitempty"`
+	Kubeconfig     *KubeconfigDataApplyConfiguration   `json:"kubeconfig,omitempty"`
+	KubeconfigFrom *KubeconfigSourceApplyConfiguration `json:"kubeconfigFrom,omitempty"`
+	Whitelist      *WhitelistApplyConfiguration        `json:"whitelist,omitempty"`
+	Approvers      *WhitelistApplyConfiguration        `json:"approvers,omitempty"`
+	RequireAuthAge *v1.Duration                        `json:"requireAuthAge,omitempty"`
+	RequiredACR    *string                             `json:"requiredACR,omitempty"`
 }
 
 // KubeconfigSpecApplyConfiguration constructs a declarative configuration of the KubeconfigSpec type for use with
@@ -40,6 +57,85 @@ func (b *KubeconfigSpecApplyConfiguration) WithName(value string) *KubeconfigSpe
 	return b
 }
 
+// WithDescription sets the Description field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Description field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithDescription(value string) *KubeconfigSpecApplyConfiguration {
+	b.Description = &value
+	return b
+}
+
+// WithEnvironment sets the Environment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Environment field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithEnvironment(value string) *KubeconfigSpecApplyConfiguration {
+	b.Environment = &value
+	return b
+}
+
+// WithRegion sets the Region field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Region field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithRegion(value string) *KubeconfigSpecApplyConfiguration {
+	b.Region = &value
+	return b
+}
+
+// WithProvider sets the Provider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provider field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithProvider(value string) *KubeconfigSpecApplyConfiguration {
+	b.Provider = &value
+	return b
+}
+
+// WithOwner sets the Owner field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Owner field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithOwner(value string) *KubeconfigSpecApplyConfiguration {
+	b.Owner = &value
+	return b
+}
+
+// WithContact sets the Contact field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Contact field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithContact(value string) *KubeconfigSpecApplyConfiguration {
+	b.Contact = &value
+	return b
+}
+
+// WithLinks adds the given value to the Links field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Links field.
+func (b *KubeconfigSpecApplyConfiguration) WithLinks(values ...*LinkApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithLinks")
+		}
+		b.Links = append(b.Links, *values[i])
+	}
+	return b
+}
+
+// WithTags adds the given value to the Tags field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tags field.
+func (b *KubeconfigSpecApplyConfiguration) WithTags(values ...string) *KubeconfigSpecApplyConfiguration {
+	for i := range values {
+		b.Tags = append(b.Tags, values[i])
+	}
+	return b
+}
+
+// WithLifecycle sets the Lifecycle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Lifecycle field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithLifecycle(value *LifecycleApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.Lifecycle = value
+	return b
+}
+
 // WithKubeconfig sets the Kubeconfig field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kubeconfig field is set to the value of the last call.
@@ -48,6 +144,14 @@ func (b *KubeconfigSpecApplyConfiguration) WithKubeconfig(value *KubeconfigDataA
 	return b
 }
 
+// WithKubeconfigFrom sets the KubeconfigFrom field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubeconfigFrom field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithKubeconfigFrom(value *KubeconfigSourceApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.KubeconfigFrom = value
+	return b
+}
+
 // WithWhitelist sets the Whitelist field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Whitelist field is set to the value of the last call.
@@ -55,3 +159,27 @@ func (b *KubeconfigSpecApplyConfiguration) WithWhitelist(value *WhitelistApplyCo
 	b.Whitelist = value
 	return b
 }
+
+// WithApprovers sets the Approvers field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Approvers field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithApprovers(value *WhitelistApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.Approvers = value
+	return b
+}
+
+// WithRequireAuthAge sets the RequireAuthAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequireAuthAge field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithRequireAuthAge(value v1.Duration) *KubeconfigSpecApplyConfiguration {
+	b.RequireAuthAge = &value
+	return b
+}
+
+// WithRequiredACR sets the RequiredACR field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequiredACR field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithRequiredACR(value string) *KubeconfigSpecApplyConfiguration {
+	b.RequiredACR = &value
+	return b
+}
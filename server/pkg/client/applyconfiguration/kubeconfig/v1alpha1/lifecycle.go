@@ -0,0 +1,71 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LifecycleApplyConfiguration represents a declarative configuration of the Lifecycle type for use
+// with apply.
+type LifecycleApplyConfiguration struct {
+	State       *kubeconfigv1alpha1.LifecycleState `json:"state,omitempty"`
+	Message     *string                            `json:"message,omitempty"`
+	SunsetDate  *v1.Time                           `json:"sunsetDate,omitempty"`
+	Replacement *string                            `json:"replacement,omitempty"`
+}
+
+// LifecycleApplyConfiguration constructs a declarative configuration of the Lifecycle type for use with
+// apply.
+func Lifecycle() *LifecycleApplyConfiguration {
+	return &LifecycleApplyConfiguration{}
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *LifecycleApplyConfiguration) WithState(value kubeconfigv1alpha1.LifecycleState) *LifecycleApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *LifecycleApplyConfiguration) WithMessage(value string) *LifecycleApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithSunsetDate sets the SunsetDate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SunsetDate field is set to the value of the last call.
+func (b *LifecycleApplyConfiguration) WithSunsetDate(value v1.Time) *LifecycleApplyConfiguration {
+	b.SunsetDate = &value
+	return b
+}
+
+// WithReplacement sets the Replacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replacement field is set to the value of the last call.
+func (b *LifecycleApplyConfiguration) WithReplacement(value string) *LifecycleApplyConfiguration {
+	b.Replacement = &value
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// NamedExtensionApplyConfiguration represents a declarative configuration of the NamedExtension type for use
+// with apply.
+type NamedExtensionApplyConfiguration struct {
+	Name      *string               `json:"name,omitempty"`
+	Extension *runtime.RawExtension `json:"extension,omitempty"`
+}
+
+// NamedExtensionApplyConfiguration constructs a declarative configuration of the NamedExtension type for use with
+// apply.
+func NamedExtension() *NamedExtensionApplyConfiguration {
+	return &NamedExtensionApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NamedExtensionApplyConfiguration) WithName(value string) *NamedExtensionApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithExtension sets the Extension field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Extension field is set to the value of the last call.
+func (b *NamedExtensionApplyConfiguration) WithExtension(value runtime.RawExtension) *NamedExtensionApplyConfiguration {
+	b.Extension = &value
+	return b
+}
@@ -22,6 +22,7 @@ package v1alpha1
 // with apply.
 type UserSpecApplyConfiguration struct {
 	AuthProvider *AuthProviderSpecApplyConfiguration `json:"auth-provider,omitempty"`
+	Exec         *ExecConfigApplyConfiguration       `json:"exec,omitempty"`
 }
 
 // UserSpecApplyConfiguration constructs a declarative configuration of the UserSpec type for use with
@@ -37,3 +38,11 @@ func (b *UserSpecApplyConfiguration) WithAuthProvider(value *AuthProviderSpecApp
 	b.AuthProvider = value
 	return b
 }
+
+// WithExec sets the Exec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Exec field is set to the value of the last call.
+func (b *UserSpecApplyConfiguration) WithExec(value *ExecConfigApplyConfiguration) *UserSpecApplyConfiguration {
+	b.Exec = value
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessApplyConfiguration represents a declarative configuration of the Access type for use
+// with apply.
+type AccessApplyConfiguration struct {
+	Whitelist      *v1alpha1.WhitelistApplyConfiguration `json:"whitelist,omitempty"`
+	Approvers      *v1alpha1.WhitelistApplyConfiguration `json:"approvers,omitempty"`
+	RequireAuthAge *v1.Duration                          `json:"requireAuthAge,omitempty"`
+	RequiredACR    *string                               `json:"requiredACR,omitempty"`
+}
+
+// AccessApplyConfiguration constructs a declarative configuration of the Access type for use with
+// apply.
+func Access() *AccessApplyConfiguration {
+	return &AccessApplyConfiguration{}
+}
+
+// WithWhitelist sets the Whitelist field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Whitelist field is set to the value of the last call.
+func (b *AccessApplyConfiguration) WithWhitelist(value *v1alpha1.WhitelistApplyConfiguration) *AccessApplyConfiguration {
+	b.Whitelist = value
+	return b
+}
+
+// WithApprovers sets the Approvers field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Approvers field is set to the value of the last call.
+func (b *AccessApplyConfiguration) WithApprovers(value *v1alpha1.WhitelistApplyConfiguration) *AccessApplyConfiguration {
+	b.Approvers = value
+	return b
+}
+
+// WithRequireAuthAge sets the RequireAuthAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequireAuthAge field is set to the value of the last call.
+func (b *AccessApplyConfiguration) WithRequireAuthAge(value v1.Duration) *AccessApplyConfiguration {
+	b.RequireAuthAge = &value
+	return b
+}
+
+// WithRequiredACR sets the RequiredACR field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequiredACR field is set to the value of the last call.
+func (b *AccessApplyConfiguration) WithRequiredACR(value string) *AccessApplyConfiguration {
+	b.RequiredACR = &value
+	return b
+}
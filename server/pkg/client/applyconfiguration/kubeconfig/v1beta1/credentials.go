@@ -0,0 +1,43 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
+)
+
+// CredentialsApplyConfiguration represents a declarative configuration of the Credentials type for use
+// with apply.
+type CredentialsApplyConfiguration struct {
+	Mode *kubeconfigv1beta1.CredentialMode `json:"mode,omitempty"`
+}
+
+// CredentialsApplyConfiguration constructs a declarative configuration of the Credentials type for use with
+// apply.
+func Credentials() *CredentialsApplyConfiguration {
+	return &CredentialsApplyConfiguration{}
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *CredentialsApplyConfiguration) WithMode(value kubeconfigv1beta1.CredentialMode) *CredentialsApplyConfiguration {
+	b.Mode = &value
+	return b
+}
@@ -0,0 +1,122 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+)
+
+// DisplayApplyConfiguration represents a declarative configuration of the Display type for use
+// with apply.
+type DisplayApplyConfiguration struct {
+	Name        *string                           `json:"name,omitempty"`
+	Description *string                           `json:"description,omitempty"`
+	Environment *string                           `json:"environment,omitempty"`
+	Region      *string                           `json:"region,omitempty"`
+	Provider    *string                           `json:"provider,omitempty"`
+	Owner       *string                           `json:"owner,omitempty"`
+	Contact     *string                           `json:"contact,omitempty"`
+	Links       []v1alpha1.LinkApplyConfiguration `json:"links,omitempty"`
+	Tags        []string                          `json:"tags,omitempty"`
+}
+
+// DisplayApplyConfiguration constructs a declarative configuration of the Display type for use with
+// apply.
+func Display() *DisplayApplyConfiguration {
+	return &DisplayApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithName(value string) *DisplayApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDescription sets the Description field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Description field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithDescription(value string) *DisplayApplyConfiguration {
+	b.Description = &value
+	return b
+}
+
+// WithEnvironment sets the Environment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Environment field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithEnvironment(value string) *DisplayApplyConfiguration {
+	b.Environment = &value
+	return b
+}
+
+// WithRegion sets the Region field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Region field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithRegion(value string) *DisplayApplyConfiguration {
+	b.Region = &value
+	return b
+}
+
+// WithProvider sets the Provider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provider field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithProvider(value string) *DisplayApplyConfiguration {
+	b.Provider = &value
+	return b
+}
+
+// WithOwner sets the Owner field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Owner field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithOwner(value string) *DisplayApplyConfiguration {
+	b.Owner = &value
+	return b
+}
+
+// WithContact sets the Contact field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Contact field is set to the value of the last call.
+func (b *DisplayApplyConfiguration) WithContact(value string) *DisplayApplyConfiguration {
+	b.Contact = &value
+	return b
+}
+
+// WithLinks adds the given value to the Links field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Links field.
+func (b *DisplayApplyConfiguration) WithLinks(values ...*v1alpha1.LinkApplyConfiguration) *DisplayApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithLinks")
+		}
+		b.Links = append(b.Links, *values[i])
+	}
+	return b
+}
+
+// WithTags adds the given value to the Tags field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tags field.
+func (b *DisplayApplyConfiguration) WithTags(values ...string) *DisplayApplyConfiguration {
+	for i := range values {
+		b.Tags = append(b.Tags, values[i])
+	}
+	return b
+}
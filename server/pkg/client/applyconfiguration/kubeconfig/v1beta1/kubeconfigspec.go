@@ -0,0 +1,116 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+)
+
+// KubeconfigSpecApplyConfiguration represents a declarative configuration of the KubeconfigSpec type for use
+// with apply.
+type KubeconfigSpecApplyConfiguration struct {
+	Display        *DisplayApplyConfiguration                   `json:"display,omitempty"`
+	Clusters       []v1alpha1.ClusterApplyConfiguration         `json:"clusters,omitempty"`
+	Contexts       []v1alpha1.ContextApplyConfiguration         `json:"contexts,omitempty"`
+	CurrentContext *string                                      `json:"currentContext,omitempty"`
+	KubeconfigFrom *v1alpha1.KubeconfigSourceApplyConfiguration `json:"kubeconfigFrom,omitempty"`
+	Lifecycle      *v1alpha1.LifecycleApplyConfiguration        `json:"lifecycle,omitempty"`
+	Access         *AccessApplyConfiguration                    `json:"access,omitempty"`
+	Credentials    *CredentialsApplyConfiguration               `json:"credentials,omitempty"`
+}
+
+// KubeconfigSpecApplyConfiguration constructs a declarative configuration of the KubeconfigSpec type for use with
+// apply.
+func KubeconfigSpec() *KubeconfigSpecApplyConfiguration {
+	return &KubeconfigSpecApplyConfiguration{}
+}
+
+// WithDisplay sets the Display field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Display field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithDisplay(value *DisplayApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.Display = value
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *KubeconfigSpecApplyConfiguration) WithClusters(values ...*v1alpha1.ClusterApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClusters")
+		}
+		b.Clusters = append(b.Clusters, *values[i])
+	}
+	return b
+}
+
+// WithContexts adds the given value to the Contexts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Contexts field.
+func (b *KubeconfigSpecApplyConfiguration) WithContexts(values ...*v1alpha1.ContextApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithContexts")
+		}
+		b.Contexts = append(b.Contexts, *values[i])
+	}
+	return b
+}
+
+// WithCurrentContext sets the CurrentContext field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentContext field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithCurrentContext(value string) *KubeconfigSpecApplyConfiguration {
+	b.CurrentContext = &value
+	return b
+}
+
+// WithKubeconfigFrom sets the KubeconfigFrom field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubeconfigFrom field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithKubeconfigFrom(value *v1alpha1.KubeconfigSourceApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.KubeconfigFrom = value
+	return b
+}
+
+// WithLifecycle sets the Lifecycle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Lifecycle field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithLifecycle(value *v1alpha1.LifecycleApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.Lifecycle = value
+	return b
+}
+
+// WithAccess sets the Access field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Access field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithAccess(value *AccessApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.Access = value
+	return b
+}
+
+// WithCredentials sets the Credentials field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Credentials field is set to the value of the last call.
+func (b *KubeconfigSpecApplyConfiguration) WithCredentials(value *CredentialsApplyConfiguration) *KubeconfigSpecApplyConfiguration {
+	b.Credentials = value
+	return b
+}
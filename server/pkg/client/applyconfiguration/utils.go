@@ -20,8 +20,10 @@ package applyconfiguration
 
 import (
 	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
 	internal "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/internal"
 	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1beta1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	testing "k8s.io/client-go/testing"
@@ -32,24 +34,62 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=kubeconfig, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("AccessGrant"):
+		return &kubeconfigv1alpha1.AccessGrantApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AccessGrantSpec"):
+		return &kubeconfigv1alpha1.AccessGrantSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AccessRequest"):
+		return &kubeconfigv1alpha1.AccessRequestApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AccessRequestSpec"):
+		return &kubeconfigv1alpha1.AccessRequestSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AccessRequestStatus"):
+		return &kubeconfigv1alpha1.AccessRequestStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Announcement"):
+		return &kubeconfigv1alpha1.AnnouncementApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AnnouncementAudience"):
+		return &kubeconfigv1alpha1.AnnouncementAudienceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AnnouncementSpec"):
+		return &kubeconfigv1alpha1.AnnouncementSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("AuthProviderConfig"):
 		return &kubeconfigv1alpha1.AuthProviderConfigApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("AuthProviderSpec"):
 		return &kubeconfigv1alpha1.AuthProviderSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Cluster"):
 		return &kubeconfigv1alpha1.ClusterApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterKubeconfig"):
+		return &kubeconfigv1alpha1.ClusterKubeconfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterStatus"):
+		return &kubeconfigv1alpha1.ClusterStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Context"):
 		return &kubeconfigv1alpha1.ContextApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ContextSpec"):
 		return &kubeconfigv1alpha1.ContextSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Details"):
 		return &kubeconfigv1alpha1.DetailsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExecConfig"):
+		return &kubeconfigv1alpha1.ExecConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GroupAlias"):
+		return &kubeconfigv1alpha1.GroupAliasApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GroupAliasSpec"):
+		return &kubeconfigv1alpha1.GroupAliasSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KeyReference"):
+		return &kubeconfigv1alpha1.KeyReferenceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Kubeconfig"):
 		return &kubeconfigv1alpha1.KubeconfigApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KubeconfigData"):
 		return &kubeconfigv1alpha1.KubeconfigDataApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KubeconfigSource"):
+		return &kubeconfigv1alpha1.KubeconfigSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KubeconfigSpec"):
 		return &kubeconfigv1alpha1.KubeconfigSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KubeconfigStatus"):
+		return &kubeconfigv1alpha1.KubeconfigStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Lifecycle"):
+		return &kubeconfigv1alpha1.LifecycleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Link"):
+		return &kubeconfigv1alpha1.LinkApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamedExtension"):
+		return &kubeconfigv1alpha1.NamedExtensionApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("User"):
 		return &kubeconfigv1alpha1.UserApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("UserSpec"):
@@ -57,6 +97,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	case v1alpha1.SchemeGroupVersion.WithKind("Whitelist"):
 		return &kubeconfigv1alpha1.WhitelistApplyConfiguration{}
 
+		// Group=kubeconfig, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("Access"):
+		return &kubeconfigv1beta1.AccessApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Credentials"):
+		return &kubeconfigv1beta1.CredentialsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Display"):
+		return &kubeconfigv1beta1.DisplayApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Kubeconfig"):
+		return &kubeconfigv1beta1.KubeconfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("KubeconfigSpec"):
+		return &kubeconfigv1beta1.KubeconfigSpecApplyConfiguration{}
+
 	}
 	return nil
 }
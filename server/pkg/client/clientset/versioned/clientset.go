@@ -23,6 +23,7 @@ import (
 	http "net/http"
 
 	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1"
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -31,12 +32,14 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	KubeconfigV1alpha1() kubeconfigv1alpha1.KubeconfigV1alpha1Interface
+	KubeconfigV1beta1() kubeconfigv1beta1.KubeconfigV1beta1Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	kubeconfigV1alpha1 *kubeconfigv1alpha1.KubeconfigV1alpha1Client
+	kubeconfigV1beta1  *kubeconfigv1beta1.KubeconfigV1beta1Client
 }
 
 // KubeconfigV1alpha1 retrieves the KubeconfigV1alpha1Client
@@ -44,6 +47,11 @@ func (c *Clientset) KubeconfigV1alpha1() kubeconfigv1alpha1.KubeconfigV1alpha1In
 	return c.kubeconfigV1alpha1
 }
 
+// KubeconfigV1beta1 retrieves the KubeconfigV1beta1Client
+func (c *Clientset) KubeconfigV1beta1() kubeconfigv1beta1.KubeconfigV1beta1Interface {
+	return c.kubeconfigV1beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -92,6 +100,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.kubeconfigV1beta1, err = kubeconfigv1beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -114,6 +126,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.kubeconfigV1alpha1 = kubeconfigv1alpha1.New(c)
+	cs.kubeconfigV1beta1 = kubeconfigv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -23,6 +23,8 @@ import (
 	clientset "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned"
 	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1"
 	fakekubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1/fake"
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1beta1"
+	fakekubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -120,3 +122,8 @@ var (
 func (c *Clientset) KubeconfigV1alpha1() kubeconfigv1alpha1.KubeconfigV1alpha1Interface {
 	return &fakekubeconfigv1alpha1.FakeKubeconfigV1alpha1{Fake: &c.Fake}
 }
+
+// KubeconfigV1beta1 retrieves the KubeconfigV1beta1Client
+func (c *Clientset) KubeconfigV1beta1() kubeconfigv1beta1.KubeconfigV1beta1Interface {
+	return &fakekubeconfigv1beta1.FakeKubeconfigV1beta1{Fake: &c.Fake}
+}
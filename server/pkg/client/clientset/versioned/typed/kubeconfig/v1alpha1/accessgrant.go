@@ -0,0 +1,70 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	applyconfigurationkubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	scheme "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// AccessGrantsGetter has a method to return a AccessGrantInterface.
+// A group's client should implement this interface.
+type AccessGrantsGetter interface {
+	AccessGrants(namespace string) AccessGrantInterface
+}
+
+// AccessGrantInterface has methods to work with AccessGrant resources.
+type AccessGrantInterface interface {
+	Create(ctx context.Context, accessGrant *kubeconfigv1alpha1.AccessGrant, opts v1.CreateOptions) (*kubeconfigv1alpha1.AccessGrant, error)
+	Update(ctx context.Context, accessGrant *kubeconfigv1alpha1.AccessGrant, opts v1.UpdateOptions) (*kubeconfigv1alpha1.AccessGrant, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*kubeconfigv1alpha1.AccessGrant, error)
+	List(ctx context.Context, opts v1.ListOptions) (*kubeconfigv1alpha1.AccessGrantList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubeconfigv1alpha1.AccessGrant, err error)
+	Apply(ctx context.Context, accessGrant *applyconfigurationkubeconfigv1alpha1.AccessGrantApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1alpha1.AccessGrant, err error)
+	AccessGrantExpansion
+}
+
+// accessGrants implements AccessGrantInterface
+type accessGrants struct {
+	*gentype.ClientWithListAndApply[*kubeconfigv1alpha1.AccessGrant, *kubeconfigv1alpha1.AccessGrantList, *applyconfigurationkubeconfigv1alpha1.AccessGrantApplyConfiguration]
+}
+
+// newAccessGrants returns a AccessGrants
+func newAccessGrants(c *KubeconfigV1alpha1Client, namespace string) *accessGrants {
+	return &accessGrants{
+		gentype.NewClientWithListAndApply[*kubeconfigv1alpha1.AccessGrant, *kubeconfigv1alpha1.AccessGrantList, *applyconfigurationkubeconfigv1alpha1.AccessGrantApplyConfiguration](
+			"accessgrants",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *kubeconfigv1alpha1.AccessGrant { return &kubeconfigv1alpha1.AccessGrant{} },
+			func() *kubeconfigv1alpha1.AccessGrantList { return &kubeconfigv1alpha1.AccessGrantList{} },
+		),
+	}
+}
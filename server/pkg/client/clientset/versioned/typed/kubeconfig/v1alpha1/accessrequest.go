@@ -0,0 +1,74 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	applyconfigurationkubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	scheme "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// AccessRequestsGetter has a method to return a AccessRequestInterface.
+// A group's client should implement this interface.
+type AccessRequestsGetter interface {
+	AccessRequests(namespace string) AccessRequestInterface
+}
+
+// AccessRequestInterface has methods to work with AccessRequest resources.
+type AccessRequestInterface interface {
+	Create(ctx context.Context, accessRequest *kubeconfigv1alpha1.AccessRequest, opts v1.CreateOptions) (*kubeconfigv1alpha1.AccessRequest, error)
+	Update(ctx context.Context, accessRequest *kubeconfigv1alpha1.AccessRequest, opts v1.UpdateOptions) (*kubeconfigv1alpha1.AccessRequest, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, accessRequest *kubeconfigv1alpha1.AccessRequest, opts v1.UpdateOptions) (*kubeconfigv1alpha1.AccessRequest, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*kubeconfigv1alpha1.AccessRequest, error)
+	List(ctx context.Context, opts v1.ListOptions) (*kubeconfigv1alpha1.AccessRequestList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubeconfigv1alpha1.AccessRequest, err error)
+	Apply(ctx context.Context, accessRequest *applyconfigurationkubeconfigv1alpha1.AccessRequestApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1alpha1.AccessRequest, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, accessRequest *applyconfigurationkubeconfigv1alpha1.AccessRequestApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1alpha1.AccessRequest, err error)
+	AccessRequestExpansion
+}
+
+// accessRequests implements AccessRequestInterface
+type accessRequests struct {
+	*gentype.ClientWithListAndApply[*kubeconfigv1alpha1.AccessRequest, *kubeconfigv1alpha1.AccessRequestList, *applyconfigurationkubeconfigv1alpha1.AccessRequestApplyConfiguration]
+}
+
+// newAccessRequests returns a AccessRequests
+func newAccessRequests(c *KubeconfigV1alpha1Client, namespace string) *accessRequests {
+	return &accessRequests{
+		gentype.NewClientWithListAndApply[*kubeconfigv1alpha1.AccessRequest, *kubeconfigv1alpha1.AccessRequestList, *applyconfigurationkubeconfigv1alpha1.AccessRequestApplyConfiguration](
+			"accessrequests",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *kubeconfigv1alpha1.AccessRequest { return &kubeconfigv1alpha1.AccessRequest{} },
+			func() *kubeconfigv1alpha1.AccessRequestList { return &kubeconfigv1alpha1.AccessRequestList{} },
+		),
+	}
+}
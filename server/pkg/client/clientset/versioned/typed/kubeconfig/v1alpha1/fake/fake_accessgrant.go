@@ -0,0 +1,53 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	typedkubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeAccessGrants implements AccessGrantInterface
+type fakeAccessGrants struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.AccessGrant, *v1alpha1.AccessGrantList, *kubeconfigv1alpha1.AccessGrantApplyConfiguration]
+	Fake *FakeKubeconfigV1alpha1
+}
+
+func newFakeAccessGrants(fake *FakeKubeconfigV1alpha1, namespace string) typedkubeconfigv1alpha1.AccessGrantInterface {
+	return &fakeAccessGrants{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.AccessGrant, *v1alpha1.AccessGrantList, *kubeconfigv1alpha1.AccessGrantApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("accessgrants"),
+			v1alpha1.SchemeGroupVersion.WithKind("AccessGrant"),
+			func() *v1alpha1.AccessGrant { return &v1alpha1.AccessGrant{} },
+			func() *v1alpha1.AccessGrantList { return &v1alpha1.AccessGrantList{} },
+			func(dst, src *v1alpha1.AccessGrantList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.AccessGrantList) []*v1alpha1.AccessGrant {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.AccessGrantList, items []*v1alpha1.AccessGrant) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
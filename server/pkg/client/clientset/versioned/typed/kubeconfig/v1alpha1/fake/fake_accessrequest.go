@@ -0,0 +1,53 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	typedkubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeAccessRequests implements AccessRequestInterface
+type fakeAccessRequests struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.AccessRequest, *v1alpha1.AccessRequestList, *kubeconfigv1alpha1.AccessRequestApplyConfiguration]
+	Fake *FakeKubeconfigV1alpha1
+}
+
+func newFakeAccessRequests(fake *FakeKubeconfigV1alpha1, namespace string) typedkubeconfigv1alpha1.AccessRequestInterface {
+	return &fakeAccessRequests{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.AccessRequest, *v1alpha1.AccessRequestList, *kubeconfigv1alpha1.AccessRequestApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("accessrequests"),
+			v1alpha1.SchemeGroupVersion.WithKind("AccessRequest"),
+			func() *v1alpha1.AccessRequest { return &v1alpha1.AccessRequest{} },
+			func() *v1alpha1.AccessRequestList { return &v1alpha1.AccessRequestList{} },
+			func(dst, src *v1alpha1.AccessRequestList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.AccessRequestList) []*v1alpha1.AccessRequest {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.AccessRequestList, items []*v1alpha1.AccessRequest) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	typedkubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterKubeconfigs implements ClusterKubeconfigInterface
+type fakeClusterKubeconfigs struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.ClusterKubeconfig, *v1alpha1.ClusterKubeconfigList, *kubeconfigv1alpha1.ClusterKubeconfigApplyConfiguration]
+	Fake *FakeKubeconfigV1alpha1
+}
+
+func newFakeClusterKubeconfigs(fake *FakeKubeconfigV1alpha1) typedkubeconfigv1alpha1.ClusterKubeconfigInterface {
+	return &fakeClusterKubeconfigs{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.ClusterKubeconfig, *v1alpha1.ClusterKubeconfigList, *kubeconfigv1alpha1.ClusterKubeconfigApplyConfiguration](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusterkubeconfigs"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterKubeconfig"),
+			func() *v1alpha1.ClusterKubeconfig { return &v1alpha1.ClusterKubeconfig{} },
+			func() *v1alpha1.ClusterKubeconfigList { return &v1alpha1.ClusterKubeconfigList{} },
+			func(dst, src *v1alpha1.ClusterKubeconfigList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterKubeconfigList) []*v1alpha1.ClusterKubeconfig {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterKubeconfigList, items []*v1alpha1.ClusterKubeconfig) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
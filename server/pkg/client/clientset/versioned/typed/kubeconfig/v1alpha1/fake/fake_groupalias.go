@@ -0,0 +1,51 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1alpha1"
+	typedkubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeGroupAliases implements GroupAliasInterface
+type fakeGroupAliases struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.GroupAlias, *v1alpha1.GroupAliasList, *kubeconfigv1alpha1.GroupAliasApplyConfiguration]
+	Fake *FakeKubeconfigV1alpha1
+}
+
+func newFakeGroupAliases(fake *FakeKubeconfigV1alpha1, namespace string) typedkubeconfigv1alpha1.GroupAliasInterface {
+	return &fakeGroupAliases{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.GroupAlias, *v1alpha1.GroupAliasList, *kubeconfigv1alpha1.GroupAliasApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("groupaliases"),
+			v1alpha1.SchemeGroupVersion.WithKind("GroupAlias"),
+			func() *v1alpha1.GroupAlias { return &v1alpha1.GroupAlias{} },
+			func() *v1alpha1.GroupAliasList { return &v1alpha1.GroupAliasList{} },
+			func(dst, src *v1alpha1.GroupAliasList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.GroupAliasList) []*v1alpha1.GroupAlias { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1alpha1.GroupAliasList, items []*v1alpha1.GroupAlias) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -28,6 +28,26 @@ type FakeKubeconfigV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKubeconfigV1alpha1) AccessGrants(namespace string) v1alpha1.AccessGrantInterface {
+	return newFakeAccessGrants(c, namespace)
+}
+
+func (c *FakeKubeconfigV1alpha1) AccessRequests(namespace string) v1alpha1.AccessRequestInterface {
+	return newFakeAccessRequests(c, namespace)
+}
+
+func (c *FakeKubeconfigV1alpha1) Announcements(namespace string) v1alpha1.AnnouncementInterface {
+	return newFakeAnnouncements(c, namespace)
+}
+
+func (c *FakeKubeconfigV1alpha1) ClusterKubeconfigs() v1alpha1.ClusterKubeconfigInterface {
+	return newFakeClusterKubeconfigs(c)
+}
+
+func (c *FakeKubeconfigV1alpha1) GroupAliases(namespace string) v1alpha1.GroupAliasInterface {
+	return newFakeGroupAliases(c, namespace)
+}
+
 func (c *FakeKubeconfigV1alpha1) Kubeconfigs(namespace string) v1alpha1.KubeconfigInterface {
 	return newFakeKubeconfigs(c, namespace)
 }
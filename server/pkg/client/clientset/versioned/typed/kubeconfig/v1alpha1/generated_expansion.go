@@ -18,4 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+type AccessGrantExpansion interface{}
+
+type AccessRequestExpansion interface{}
+
+type AnnouncementExpansion interface{}
+
+type ClusterKubeconfigExpansion interface{}
+
+type GroupAliasExpansion interface{}
+
 type KubeconfigExpansion interface{}
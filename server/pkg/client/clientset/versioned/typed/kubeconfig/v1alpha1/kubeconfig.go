@@ -40,6 +40,8 @@ type KubeconfigsGetter interface {
 type KubeconfigInterface interface {
 	Create(ctx context.Context, kubeconfig *kubeconfigv1alpha1.Kubeconfig, opts v1.CreateOptions) (*kubeconfigv1alpha1.Kubeconfig, error)
 	Update(ctx context.Context, kubeconfig *kubeconfigv1alpha1.Kubeconfig, opts v1.UpdateOptions) (*kubeconfigv1alpha1.Kubeconfig, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, kubeconfig *kubeconfigv1alpha1.Kubeconfig, opts v1.UpdateOptions) (*kubeconfigv1alpha1.Kubeconfig, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*kubeconfigv1alpha1.Kubeconfig, error)
@@ -47,6 +49,8 @@ type KubeconfigInterface interface {
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubeconfigv1alpha1.Kubeconfig, err error)
 	Apply(ctx context.Context, kubeconfig *applyconfigurationkubeconfigv1alpha1.KubeconfigApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1alpha1.Kubeconfig, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, kubeconfig *applyconfigurationkubeconfigv1alpha1.KubeconfigApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1alpha1.Kubeconfig, err error)
 	KubeconfigExpansion
 }
 
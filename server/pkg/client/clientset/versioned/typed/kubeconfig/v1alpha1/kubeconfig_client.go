@@ -28,6 +28,11 @@ import (
 
 type KubeconfigV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AccessGrantsGetter
+	AccessRequestsGetter
+	AnnouncementsGetter
+	ClusterKubeconfigsGetter
+	GroupAliasesGetter
 	KubeconfigsGetter
 }
 
@@ -36,6 +41,26 @@ type KubeconfigV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KubeconfigV1alpha1Client) AccessGrants(namespace string) AccessGrantInterface {
+	return newAccessGrants(c, namespace)
+}
+
+func (c *KubeconfigV1alpha1Client) AccessRequests(namespace string) AccessRequestInterface {
+	return newAccessRequests(c, namespace)
+}
+
+func (c *KubeconfigV1alpha1Client) Announcements(namespace string) AnnouncementInterface {
+	return newAnnouncements(c, namespace)
+}
+
+func (c *KubeconfigV1alpha1Client) ClusterKubeconfigs() ClusterKubeconfigInterface {
+	return newClusterKubeconfigs(c)
+}
+
+func (c *KubeconfigV1alpha1Client) GroupAliases(namespace string) GroupAliasInterface {
+	return newGroupAliases(c, namespace)
+}
+
 func (c *KubeconfigV1alpha1Client) Kubeconfigs(namespace string) KubeconfigInterface {
 	return newKubeconfigs(c, namespace)
 }
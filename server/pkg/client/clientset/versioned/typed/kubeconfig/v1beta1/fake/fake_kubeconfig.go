@@ -0,0 +1,51 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1beta1"
+	typedkubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/typed/kubeconfig/v1beta1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeKubeconfigs implements KubeconfigInterface
+type fakeKubeconfigs struct {
+	*gentype.FakeClientWithListAndApply[*v1beta1.Kubeconfig, *v1beta1.KubeconfigList, *kubeconfigv1beta1.KubeconfigApplyConfiguration]
+	Fake *FakeKubeconfigV1beta1
+}
+
+func newFakeKubeconfigs(fake *FakeKubeconfigV1beta1, namespace string) typedkubeconfigv1beta1.KubeconfigInterface {
+	return &fakeKubeconfigs{
+		gentype.NewFakeClientWithListAndApply[*v1beta1.Kubeconfig, *v1beta1.KubeconfigList, *kubeconfigv1beta1.KubeconfigApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("kubeconfigs"),
+			v1beta1.SchemeGroupVersion.WithKind("Kubeconfig"),
+			func() *v1beta1.Kubeconfig { return &v1beta1.Kubeconfig{} },
+			func() *v1beta1.KubeconfigList { return &v1beta1.KubeconfigList{} },
+			func(dst, src *v1beta1.KubeconfigList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.KubeconfigList) []*v1beta1.Kubeconfig { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1beta1.KubeconfigList, items []*v1beta1.Kubeconfig) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	context "context"
+
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
+	applyconfigurationkubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/client/applyconfiguration/kubeconfig/v1beta1"
+	scheme "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// KubeconfigsGetter has a method to return a KubeconfigInterface.
+// A group's client should implement this interface.
+type KubeconfigsGetter interface {
+	Kubeconfigs(namespace string) KubeconfigInterface
+}
+
+// KubeconfigInterface has methods to work with Kubeconfig resources.
+type KubeconfigInterface interface {
+	Create(ctx context.Context, kubeconfig *kubeconfigv1beta1.Kubeconfig, opts v1.CreateOptions) (*kubeconfigv1beta1.Kubeconfig, error)
+	Update(ctx context.Context, kubeconfig *kubeconfigv1beta1.Kubeconfig, opts v1.UpdateOptions) (*kubeconfigv1beta1.Kubeconfig, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, kubeconfig *kubeconfigv1beta1.Kubeconfig, opts v1.UpdateOptions) (*kubeconfigv1beta1.Kubeconfig, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*kubeconfigv1beta1.Kubeconfig, error)
+	List(ctx context.Context, opts v1.ListOptions) (*kubeconfigv1beta1.KubeconfigList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubeconfigv1beta1.Kubeconfig, err error)
+	Apply(ctx context.Context, kubeconfig *applyconfigurationkubeconfigv1beta1.KubeconfigApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1beta1.Kubeconfig, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, kubeconfig *applyconfigurationkubeconfigv1beta1.KubeconfigApplyConfiguration, opts v1.ApplyOptions) (result *kubeconfigv1beta1.Kubeconfig, err error)
+	KubeconfigExpansion
+}
+
+// kubeconfigs implements KubeconfigInterface
+type kubeconfigs struct {
+	*gentype.ClientWithListAndApply[*kubeconfigv1beta1.Kubeconfig, *kubeconfigv1beta1.KubeconfigList, *applyconfigurationkubeconfigv1beta1.KubeconfigApplyConfiguration]
+}
+
+// newKubeconfigs returns a Kubeconfigs
+func newKubeconfigs(c *KubeconfigV1beta1Client, namespace string) *kubeconfigs {
+	return &kubeconfigs{
+		gentype.NewClientWithListAndApply[*kubeconfigv1beta1.Kubeconfig, *kubeconfigv1beta1.KubeconfigList, *applyconfigurationkubeconfigv1beta1.KubeconfigApplyConfiguration](
+			"kubeconfigs",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *kubeconfigv1beta1.Kubeconfig { return &kubeconfigv1beta1.Kubeconfig{} },
+			func() *kubeconfigv1beta1.KubeconfigList { return &kubeconfigv1beta1.KubeconfigList{} },
+		),
+	}
+}
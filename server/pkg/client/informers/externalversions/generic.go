@@ -22,6 +22,7 @@ import (
 	fmt "fmt"
 
 	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -53,9 +54,23 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=kubeconfig, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("accessgrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1alpha1().AccessGrants().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("accessrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1alpha1().AccessRequests().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("announcements"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1alpha1().Announcements().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterkubeconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1alpha1().ClusterKubeconfigs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("groupaliases"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1alpha1().GroupAliases().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("kubeconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1alpha1().Kubeconfigs().Informer()}, nil
 
+		// Group=kubeconfig, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("kubeconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeconfig().V1beta1().Kubeconfigs().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
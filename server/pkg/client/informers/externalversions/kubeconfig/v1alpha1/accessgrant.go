@@ -0,0 +1,90 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apiskubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	versioned "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/AvistoTelecom/kubebrowser/pkg/client/informers/externalversions/internalinterfaces"
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AccessGrantInformer provides access to a shared informer and lister for
+// AccessGrants.
+type AccessGrantInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() kubeconfigv1alpha1.AccessGrantLister
+}
+
+type accessGrantInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAccessGrantInformer constructs a new informer for AccessGrant type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAccessGrantInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAccessGrantInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAccessGrantInformer constructs a new informer for AccessGrant type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAccessGrantInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubeconfigV1alpha1().AccessGrants(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubeconfigV1alpha1().AccessGrants(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apiskubeconfigv1alpha1.AccessGrant{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *accessGrantInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAccessGrantInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *accessGrantInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiskubeconfigv1alpha1.AccessGrant{}, f.defaultInformer)
+}
+
+func (f *accessGrantInformer) Lister() kubeconfigv1alpha1.AccessGrantLister {
+	return kubeconfigv1alpha1.NewAccessGrantLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,89 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apiskubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	versioned "github.com/AvistoTelecom/kubebrowser/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/AvistoTelecom/kubebrowser/pkg/client/informers/externalversions/internalinterfaces"
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterKubeconfigInformer provides access to a shared informer and lister for
+// ClusterKubeconfigs.
+type ClusterKubeconfigInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() kubeconfigv1alpha1.ClusterKubeconfigLister
+}
+
+type clusterKubeconfigInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterKubeconfigInformer constructs a new informer for ClusterKubeconfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterKubeconfigInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterKubeconfigInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterKubeconfigInformer constructs a new informer for ClusterKubeconfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterKubeconfigInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubeconfigV1alpha1().ClusterKubeconfigs().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubeconfigV1alpha1().ClusterKubeconfigs().Watch(context.TODO(), options)
+			},
+		},
+		&apiskubeconfigv1alpha1.ClusterKubeconfig{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterKubeconfigInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterKubeconfigInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterKubeconfigInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiskubeconfigv1alpha1.ClusterKubeconfig{}, f.defaultInformer)
+}
+
+func (f *clusterKubeconfigInformer) Lister() kubeconfigv1alpha1.ClusterKubeconfigLister {
+	return kubeconfigv1alpha1.NewClusterKubeconfigLister(f.Informer().GetIndexer())
+}
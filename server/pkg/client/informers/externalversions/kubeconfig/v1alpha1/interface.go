@@ -24,6 +24,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AccessGrants returns a AccessGrantInformer.
+	AccessGrants() AccessGrantInformer
+	// AccessRequests returns a AccessRequestInformer.
+	AccessRequests() AccessRequestInformer
+	// Announcements returns a AnnouncementInformer.
+	Announcements() AnnouncementInformer
+	// ClusterKubeconfigs returns a ClusterKubeconfigInformer.
+	ClusterKubeconfigs() ClusterKubeconfigInformer
+	// GroupAliases returns a GroupAliasInformer.
+	GroupAliases() GroupAliasInformer
 	// Kubeconfigs returns a KubeconfigInformer.
 	Kubeconfigs() KubeconfigInformer
 }
@@ -39,6 +49,31 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AccessGrants returns a AccessGrantInformer.
+func (v *version) AccessGrants() AccessGrantInformer {
+	return &accessGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// AccessRequests returns a AccessRequestInformer.
+func (v *version) AccessRequests() AccessRequestInformer {
+	return &accessRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Announcements returns a AnnouncementInformer.
+func (v *version) Announcements() AnnouncementInformer {
+	return &announcementInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterKubeconfigs returns a ClusterKubeconfigInformer.
+func (v *version) ClusterKubeconfigs() ClusterKubeconfigInformer {
+	return &clusterKubeconfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// GroupAliases returns a GroupAliasInformer.
+func (v *version) GroupAliases() GroupAliasInformer {
+	return &groupAliasInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Kubeconfigs returns a KubeconfigInformer.
 func (v *version) Kubeconfigs() KubeconfigInformer {
 	return &kubeconfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
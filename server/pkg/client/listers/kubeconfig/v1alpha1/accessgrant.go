@@ -0,0 +1,70 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AccessGrantLister helps list AccessGrants.
+// All objects returned here must be treated as read-only.
+type AccessGrantLister interface {
+	// List lists all AccessGrants in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*kubeconfigv1alpha1.AccessGrant, err error)
+	// AccessGrants returns an object that can list and get AccessGrants.
+	AccessGrants(namespace string) AccessGrantNamespaceLister
+	AccessGrantListerExpansion
+}
+
+// accessGrantLister implements the AccessGrantLister interface.
+type accessGrantLister struct {
+	listers.ResourceIndexer[*kubeconfigv1alpha1.AccessGrant]
+}
+
+// NewAccessGrantLister returns a new AccessGrantLister.
+func NewAccessGrantLister(indexer cache.Indexer) AccessGrantLister {
+	return &accessGrantLister{listers.New[*kubeconfigv1alpha1.AccessGrant](indexer, kubeconfigv1alpha1.Resource("accessgrant"))}
+}
+
+// AccessGrants returns an object that can list and get AccessGrants.
+func (s *accessGrantLister) AccessGrants(namespace string) AccessGrantNamespaceLister {
+	return accessGrantNamespaceLister{listers.NewNamespaced[*kubeconfigv1alpha1.AccessGrant](s.ResourceIndexer, namespace)}
+}
+
+// AccessGrantNamespaceLister helps list and get AccessGrants.
+// All objects returned here must be treated as read-only.
+type AccessGrantNamespaceLister interface {
+	// List lists all AccessGrants in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*kubeconfigv1alpha1.AccessGrant, err error)
+	// Get retrieves the AccessGrant from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*kubeconfigv1alpha1.AccessGrant, error)
+	AccessGrantNamespaceListerExpansion
+}
+
+// accessGrantNamespaceLister implements the AccessGrantNamespaceLister
+// interface.
+type accessGrantNamespaceLister struct {
+	listers.ResourceIndexer[*kubeconfigv1alpha1.AccessGrant]
+}
@@ -0,0 +1,70 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	kubeconfigv1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AccessRequestLister helps list AccessRequests.
+// All objects returned here must be treated as read-only.
+type AccessRequestLister interface {
+	// List lists all AccessRequests in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*kubeconfigv1alpha1.AccessRequest, err error)
+	// AccessRequests returns an object that can list and get AccessRequests.
+	AccessRequests(namespace string) AccessRequestNamespaceLister
+	AccessRequestListerExpansion
+}
+
+// accessRequestLister implements the AccessRequestLister interface.
+type accessRequestLister struct {
+	listers.ResourceIndexer[*kubeconfigv1alpha1.AccessRequest]
+}
+
+// NewAccessRequestLister returns a new AccessRequestLister.
+func NewAccessRequestLister(indexer cache.Indexer) AccessRequestLister {
+	return &accessRequestLister{listers.New[*kubeconfigv1alpha1.AccessRequest](indexer, kubeconfigv1alpha1.Resource("accessrequest"))}
+}
+
+// AccessRequests returns an object that can list and get AccessRequests.
+func (s *accessRequestLister) AccessRequests(namespace string) AccessRequestNamespaceLister {
+	return accessRequestNamespaceLister{listers.NewNamespaced[*kubeconfigv1alpha1.AccessRequest](s.ResourceIndexer, namespace)}
+}
+
+// AccessRequestNamespaceLister helps list and get AccessRequests.
+// All objects returned here must be treated as read-only.
+type AccessRequestNamespaceLister interface {
+	// List lists all AccessRequests in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*kubeconfigv1alpha1.AccessRequest, err error)
+	// Get retrieves the AccessRequest from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*kubeconfigv1alpha1.AccessRequest, error)
+	AccessRequestNamespaceListerExpansion
+}
+
+// accessRequestNamespaceLister implements the AccessRequestNamespaceLister
+// interface.
+type accessRequestNamespaceLister struct {
+	listers.ResourceIndexer[*kubeconfigv1alpha1.AccessRequest]
+}
@@ -18,6 +18,42 @@ limitations under the License.
 
 package v1alpha1
 
+// AccessGrantListerExpansion allows custom methods to be added to
+// AccessGrantLister.
+type AccessGrantListerExpansion interface{}
+
+// AccessGrantNamespaceListerExpansion allows custom methods to be added to
+// AccessGrantNamespaceLister.
+type AccessGrantNamespaceListerExpansion interface{}
+
+// AccessRequestListerExpansion allows custom methods to be added to
+// AccessRequestLister.
+type AccessRequestListerExpansion interface{}
+
+// AccessRequestNamespaceListerExpansion allows custom methods to be added to
+// AccessRequestNamespaceLister.
+type AccessRequestNamespaceListerExpansion interface{}
+
+// AnnouncementListerExpansion allows custom methods to be added to
+// AnnouncementLister.
+type AnnouncementListerExpansion interface{}
+
+// AnnouncementNamespaceListerExpansion allows custom methods to be added to
+// AnnouncementNamespaceLister.
+type AnnouncementNamespaceListerExpansion interface{}
+
+// ClusterKubeconfigListerExpansion allows custom methods to be added to
+// ClusterKubeconfigLister.
+type ClusterKubeconfigListerExpansion interface{}
+
+// GroupAliasListerExpansion allows custom methods to be added to
+// GroupAliasLister.
+type GroupAliasListerExpansion interface{}
+
+// GroupAliasNamespaceListerExpansion allows custom methods to be added to
+// GroupAliasNamespaceLister.
+type GroupAliasNamespaceListerExpansion interface{}
+
 // KubeconfigListerExpansion allows custom methods to be added to
 // KubeconfigLister.
 type KubeconfigListerExpansion interface{}
@@ -0,0 +1,70 @@
+/*
+Copyright Yann Lacroix.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	kubeconfigv1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// KubeconfigLister helps list Kubeconfigs.
+// All objects returned here must be treated as read-only.
+type KubeconfigLister interface {
+	// List lists all Kubeconfigs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*kubeconfigv1beta1.Kubeconfig, err error)
+	// Kubeconfigs returns an object that can list and get Kubeconfigs.
+	Kubeconfigs(namespace string) KubeconfigNamespaceLister
+	KubeconfigListerExpansion
+}
+
+// kubeconfigLister implements the KubeconfigLister interface.
+type kubeconfigLister struct {
+	listers.ResourceIndexer[*kubeconfigv1beta1.Kubeconfig]
+}
+
+// NewKubeconfigLister returns a new KubeconfigLister.
+func NewKubeconfigLister(indexer cache.Indexer) KubeconfigLister {
+	return &kubeconfigLister{listers.New[*kubeconfigv1beta1.Kubeconfig](indexer, kubeconfigv1beta1.Resource("kubeconfig"))}
+}
+
+// Kubeconfigs returns an object that can list and get Kubeconfigs.
+func (s *kubeconfigLister) Kubeconfigs(namespace string) KubeconfigNamespaceLister {
+	return kubeconfigNamespaceLister{listers.NewNamespaced[*kubeconfigv1beta1.Kubeconfig](s.ResourceIndexer, namespace)}
+}
+
+// KubeconfigNamespaceLister helps list and get Kubeconfigs.
+// All objects returned here must be treated as read-only.
+type KubeconfigNamespaceLister interface {
+	// List lists all Kubeconfigs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*kubeconfigv1beta1.Kubeconfig, err error)
+	// Get retrieves the Kubeconfig from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*kubeconfigv1beta1.Kubeconfig, error)
+	KubeconfigNamespaceListerExpansion
+}
+
+// kubeconfigNamespaceLister implements the KubeconfigNamespaceLister
+// interface.
+type kubeconfigNamespaceLister struct {
+	listers.ResourceIndexer[*kubeconfigv1beta1.Kubeconfig]
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	probeTimeout = 5 * time.Second
+	// Number of clusters probed at the same time
+	probeConcurrency = 10
+	// Maximum size of the answers of the API servers read by probes
+	probeMaxBodySize = 64 * 1024
+)
+
+// ClusterProbe is the result of a probe of a cluster, as sent to the UI
+type ClusterProbe struct {
+	v1alpha1.ClusterStatus
+	// Duration of the version request, TLS handshake included
+	LatencyMilliseconds int64     `json:"latencyMilliseconds"`
+	ProbeTime           time.Time `json:"probeTime"`
+}
+
+// Probes the API server of a cluster: requests its version and its readiness, then checks its
+// certificates. The connection does not verify the certificates, so that they can be checked
+// even when they are invalid.
+func probeCluster(ctx context.Context, cluster v1alpha1.Cluster) ClusterProbe {
+	probe := ClusterProbe{ClusterStatus: v1alpha1.ClusterStatus{Name: cluster.Name}, ProbeTime: time.Now()}
+	details := cluster.Cluster
+
+	client, err := probeHTTPClient(details)
+	if err != nil {
+		probe.Message = err.Error()
+		return probe
+	}
+
+	start := time.Now()
+	resp, body, err := probeGet(ctx, client, details.Server, "/version")
+	probe.LatencyMilliseconds = time.Since(start).Milliseconds()
+	if err != nil {
+		probe.Message = err.Error()
+		return probe
+	}
+	probe.Reachable = true
+
+	var version struct {
+		GitVersion string `json:"gitVersion"`
+	}
+	if resp.StatusCode == http.StatusOK && json.Unmarshal(body, &version) == nil {
+		probe.Version = version.GitVersion
+	}
+
+	var messages []string
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		certErr, caErr := verifyServerCertificates(resp.TLS.PeerCertificates, details)
+		probe.CertificateValid = ptr.To(certErr == nil)
+		if certErr != nil {
+			messages = append(messages, certErr.Error())
+		}
+		probe.CertificateAuthorityValid = ptr.To(caErr == nil)
+		if caErr != nil {
+			messages = append(messages, caErr.Error())
+		}
+	}
+
+	resp, _, err = probeGet(ctx, client, details.Server, "/readyz")
+	switch {
+	case err != nil:
+		probe.Ready = ptr.To(false)
+		messages = append(messages, err.Error())
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		// Anonymous requests are not allowed, the readiness is unknown
+	default:
+		probe.Ready = ptr.To(resp.StatusCode == http.StatusOK)
+	}
+
+	probe.Message = strings.Join(messages, "; ")
+	return probe
+}
+
+// Requests a path of the API server, returning the beginning of the answer
+func probeGet(ctx context.Context, client *http.Client, server, path string) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(server, "/")+path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, probeMaxBodySize))
+	return resp, body, err
+}
+
+// Returns an HTTP client accepting any certificate, through the proxy of the cluster
+func probeHTTPClient(details v1alpha1.Details) (*http.Client, error) {
+	proxy := http.ProxyFromEnvironment
+	if details.ProxyURL != "" {
+		proxyURL, err := url.Parse(details.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{
+		Timeout: probeTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				// Checked by verifyServerCertificates
+				InsecureSkipVerify: true,
+				ServerName:         details.TLSServerName,
+			},
+			Proxy:             proxy,
+			DisableKeepAlives: true,
+		},
+	}, nil
+}
+
+// Checks the certificates presented by the API server of a cluster. The first error tells whether
+// they are out of their validity period or do not match the server name, the second whether they
+// are not signed by the certificate authority of the cluster, or by the system roots without
+// certificate authority data.
+func verifyServerCertificates(certs []*x509.Certificate, details v1alpha1.Details) (error, error) {
+	leaf := certs[0]
+	serverName := details.TLSServerName
+	if serverName == "" {
+		if serverURL, err := url.Parse(details.Server); err == nil {
+			serverName = serverURL.Hostname()
+		}
+	}
+
+	var certErr error
+	now := time.Now()
+	for _, cert := range certs {
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			certErr = fmt.Errorf("certificate %q is valid from %s to %s", cert.Subject.CommonName,
+				cert.NotBefore.UTC().Format(time.RFC3339), cert.NotAfter.UTC().Format(time.RFC3339))
+			break
+		}
+	}
+	if certErr == nil {
+		certErr = leaf.VerifyHostname(serverName)
+	}
+
+	options := x509.VerifyOptions{Intermediates: x509.NewCertPool()}
+	for _, cert := range certs[1:] {
+		options.Intermediates.AddCert(cert)
+	}
+	if details.CertificateAuthorityData != "" {
+		roots, err := parseCertificateAuthorityData(details.CertificateAuthorityData)
+		if err != nil {
+			return certErr, fmt.Errorf("certificate authority data %s", err)
+		}
+		options.Roots = x509.NewCertPool()
+		for _, root := range roots {
+			options.Roots.AddCert(root)
+		}
+	}
+	_, caErr := leaf.Verify(options)
+	return certErr, caErr
+}
+
+// Periodically probes the clusters of every Kubeconfig of the catalog, keeping the last results
+// for the API and the metrics
+type clusterProber struct {
+	mu sync.RWMutex
+	// Probes of the clusters of each Kubeconfig, by ID
+	probes map[string][]ClusterProbe
+}
+
+var clusterProbes = &clusterProber{probes: map[string][]ClusterProbe{}}
+
+// Returns the last probes of the clusters of a Kubeconfig
+func (p *clusterProber) get(id string) []ClusterProbe {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.probes[id]
+}
+
+// Probes the catalog every interval until the context is cancelled
+func (p *clusterProber) Run(ctx context.Context, interval time.Duration) {
+	logger.Infow("Starting cluster prober", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.probeCatalog(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *clusterProber) probeCatalog(ctx context.Context) {
+	kubeconfigs, err := listKubeconfigs()
+	if err != nil {
+		logger.Errorf("Error listing kubeconfigs to probe: %s", err)
+		return
+	}
+
+	probes := make(map[string][]ClusterProbe, len(kubeconfigs))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, probeConcurrency)
+	for _, kubeconfig := range kubeconfigs {
+		if kubeconfigDisabled(kubeconfig) {
+			continue
+		}
+		clusters := kubeconfig.Spec.Kubeconfig.Clusters
+		results := make([]ClusterProbe, len(clusters))
+		probes[kubeconfigID(kubeconfig)] = results
+		for i, cluster := range clusters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				slots <- struct{}{}
+				defer func() { <-slots }()
+				results[i] = probeCluster(ctx, cluster)
+			}()
+		}
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	p.mu.Lock()
+	previous := p.probes
+	p.probes = probes
+	p.mu.Unlock()
+
+	// Push the Kubeconfigs whose clusters changed state, latencies are only refreshed with them
+	var changed []string
+	for id, results := range probes {
+		if !equality.Semantic.DeepEqual(clusterStatuses(results), clusterStatuses(previous[id])) {
+			changed = append(changed, id)
+		}
+	}
+	for id := range previous {
+		if _, ok := probes[id]; !ok {
+			changed = append(changed, id)
+		}
+	}
+	if len(changed) > 0 {
+		catalogChanges.publish(changed...)
+	}
+	recordProbeMetrics(probes)
+}
+
+// Returns the statuses of the probes, without their latency and time
+func clusterStatuses(probes []ClusterProbe) []v1alpha1.ClusterStatus {
+	statuses := make([]v1alpha1.ClusterStatus, 0, len(probes))
+	for _, probe := range probes {
+		statuses = append(statuses, probe.ClusterStatus)
+	}
+	return statuses
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Answers the version and readiness requests of probes as an API server does
+var testAPIServerHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/version":
+		fmt.Fprint(w, `{"gitVersion":"v1.31.2"}`)
+	case "/readyz":
+		fmt.Fprint(w, "ok")
+	default:
+		http.NotFound(w, r)
+	}
+})
+
+// Returns base64 encoded PEM data of the certificate
+func testCertificateData(cert *x509.Certificate) string {
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// Starts a TLS server presenting a self-signed certificate for 127.0.0.1 valid until notAfter
+func testTLSServerValidUntil(t *testing.T, notAfter time.Time) *httptest.Server {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kube-apiserver"},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(testAPIServerHandler)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProbeCluster(t *testing.T) {
+	server := httptest.NewTLSServer(testAPIServerHandler)
+	t.Cleanup(server.Close)
+	serverCA := testCertificateData(server.Certificate())
+
+	closed := httptest.NewTLSServer(testAPIServerHandler)
+	closed.Close()
+
+	expired := testTLSServerValidUntil(t, time.Now().Add(-time.Hour))
+	expiredCA := testCertificateData(expired.Certificate())
+
+	tests := []struct {
+		name                          string
+		details                       v1alpha1.Details
+		wantReachable                 bool
+		wantVersion                   string
+		wantCertificateValid          *bool
+		wantCertificateAuthorityValid *bool
+	}{
+		{
+			name:                          "trusted",
+			details:                       v1alpha1.Details{Server: server.URL, CertificateAuthorityData: serverCA},
+			wantReachable:                 true,
+			wantVersion:                   "v1.31.2",
+			wantCertificateValid:          ptr.To(true),
+			wantCertificateAuthorityValid: ptr.To(true),
+		},
+		{
+			name:    "unreachable",
+			details: v1alpha1.Details{Server: closed.URL, CertificateAuthorityData: serverCA},
+		},
+		{
+			name:                          "wrong certificate authority",
+			details:                       v1alpha1.Details{Server: server.URL, CertificateAuthorityData: testCertificateAuthorityData(t, "other-ca", time.Now().Add(time.Hour))},
+			wantReachable:                 true,
+			wantVersion:                   "v1.31.2",
+			wantCertificateValid:          ptr.To(true),
+			wantCertificateAuthorityValid: ptr.To(false),
+		},
+		{
+			name:                          "no certificate authority data",
+			details:                       v1alpha1.Details{Server: server.URL},
+			wantReachable:                 true,
+			wantVersion:                   "v1.31.2",
+			wantCertificateValid:          ptr.To(true),
+			wantCertificateAuthorityValid: ptr.To(false),
+		},
+		{
+			name:                          "expired certificate",
+			details:                       v1alpha1.Details{Server: expired.URL, CertificateAuthorityData: expiredCA},
+			wantReachable:                 true,
+			wantVersion:                   "v1.31.2",
+			wantCertificateValid:          ptr.To(false),
+			wantCertificateAuthorityValid: ptr.To(false),
+		},
+		{
+			name:                          "server name mismatch",
+			details:                       v1alpha1.Details{Server: server.URL, CertificateAuthorityData: serverCA, TLSServerName: "kubernetes.default"},
+			wantReachable:                 true,
+			wantVersion:                   "v1.31.2",
+			wantCertificateValid:          ptr.To(false),
+			wantCertificateAuthorityValid: ptr.To(true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := probeCluster(context.Background(), v1alpha1.Cluster{Name: "prod", Cluster: tt.details})
+			if probe.Name != "prod" {
+				t.Errorf("Name = %q, want prod", probe.Name)
+			}
+			if probe.Reachable != tt.wantReachable {
+				t.Errorf("Reachable = %v, want %v (message %q)", probe.Reachable, tt.wantReachable, probe.Message)
+			}
+			if !tt.wantReachable && probe.Message == "" {
+				t.Error("Message is empty for an unreachable cluster")
+			}
+			if probe.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", probe.Version, tt.wantVersion)
+			}
+			if !ptr.Equal(probe.CertificateValid, tt.wantCertificateValid) {
+				t.Errorf("CertificateValid = %v, want %v (message %q)", ptr.Deref(probe.CertificateValid, false), ptr.Deref(tt.wantCertificateValid, false), probe.Message)
+			}
+			if !ptr.Equal(probe.CertificateAuthorityValid, tt.wantCertificateAuthorityValid) {
+				t.Errorf("CertificateAuthorityValid = %v, want %v (message %q)", ptr.Deref(probe.CertificateAuthorityValid, false), ptr.Deref(tt.wantCertificateAuthorityValid, false), probe.Message)
+			}
+			if tt.wantReachable && !ptr.Equal(probe.Ready, ptr.To(true)) {
+				t.Errorf("Ready = %v, want true", probe.Ready)
+			}
+		})
+	}
+}
+
+func TestReachableCondition(t *testing.T) {
+	spec := testKubeconfigSpec("Production")
+	spec.Kubeconfig.Clusters = append(spec.Kubeconfig.Clusters, v1alpha1.Cluster{
+		Name:    "lab",
+		Cluster: v1alpha1.Details{Server: "https://lab.example.com:6443", InsecureSkipTLSVerify: true},
+	})
+	kubeconfig := &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "prod", Generation: 3}, Spec: spec}
+
+	probe := func(name string, reachable, trusted bool) ClusterProbe {
+		status := v1alpha1.ClusterStatus{Name: name, Reachable: reachable, Message: name + " message"}
+		if reachable {
+			status.CertificateValid = ptr.To(true)
+			status.CertificateAuthorityValid = ptr.To(trusted)
+		}
+		return ClusterProbe{ClusterStatus: status}
+	}
+
+	tests := []struct {
+		name       string
+		probes     []ClusterProbe
+		wantOK     bool
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:   "not probed",
+			probes: nil,
+		},
+		{
+			name:   "cluster not probed yet",
+			probes: []ClusterProbe{probe("prod", true, true)},
+		},
+		{
+			name:       "reachable",
+			probes:     []ClusterProbe{probe("prod", true, true), probe("lab", true, true)},
+			wantOK:     true,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Reachable",
+		},
+		{
+			name:       "untrusted cluster skipping TLS verification",
+			probes:     []ClusterProbe{probe("lab", true, false), probe("prod", true, true)},
+			wantOK:     true,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Reachable",
+		},
+		{
+			name:       "untrusted",
+			probes:     []ClusterProbe{probe("prod", true, false), probe("lab", true, true)},
+			wantOK:     true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "CertificateInvalid",
+		},
+		{
+			name:       "unreachable",
+			probes:     []ClusterProbe{probe("prod", true, false), probe("lab", false, false)},
+			wantOK:     true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "Unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters, condition, ok := reachableCondition(kubeconfig, tt.probes)
+			if ok != tt.wantOK {
+				t.Fatalf("reachableCondition() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("condition = %s %s, want %s %s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if condition.ObservedGeneration != 3 {
+				t.Errorf("condition observed generation = %d, want 3", condition.ObservedGeneration)
+			}
+			names := make([]string, 0, len(clusters))
+			for _, cluster := range clusters {
+				names = append(names, cluster.Name)
+			}
+			if want := []string{"prod", "lab"}; !slices.Equal(names, want) {
+				t.Errorf("clusters = %q, want %q", names, want)
+			}
+		})
+	}
+}
+
+// Returns the label values of the series of a probe metric, ordered by label name
+func probeMetricSeriesOf(t *testing.T, name string) []string {
+	t.Helper()
+	// The default registry also collects the certificate authorities of the catalog
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(clusterUp, clusterReady, clusterProbeDuration, clusterCertificateValid, clusterCertificateAuthorityValid, clusterInfo)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var series []string
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			var values []string
+			for _, label := range metric.GetLabel() {
+				values = append(values, label.GetValue())
+			}
+			series = append(series, fmt.Sprint(values))
+		}
+	}
+	slices.Sort(series)
+	return series
+}
+
+func TestRecordProbeMetrics(t *testing.T) {
+	t.Cleanup(func() { recordProbeMetrics(nil) })
+
+	reachable := ClusterProbe{ClusterStatus: v1alpha1.ClusterStatus{
+		Name:                      "prod",
+		Reachable:                 true,
+		Version:                   "v1.31.2",
+		Ready:                     ptr.To(true),
+		CertificateValid:          ptr.To(true),
+		CertificateAuthorityValid: ptr.To(true),
+	}}
+	unreachable := ClusterProbe{ClusterStatus: v1alpha1.ClusterStatus{Name: "prod"}}
+
+	recordProbeMetrics(map[string][]ClusterProbe{"prod": {reachable}, "team-a/dev": {unreachable}})
+	if got, want := probeMetricSeriesOf(t, "kubebrowser_cluster_up"), []string{"[prod prod]", "[prod team-a/dev]"}; !slices.Equal(got, want) {
+		t.Errorf("cluster_up series = %q, want %q", got, want)
+	}
+	if got, want := probeMetricSeriesOf(t, "kubebrowser_cluster_info"), []string{"[prod prod v1.31.2]"}; !slices.Equal(got, want) {
+		t.Errorf("cluster_info series = %q, want %q", got, want)
+	}
+
+	// The removed Kubeconfig and the metrics of the cluster that became unreachable are deleted
+	recordProbeMetrics(map[string][]ClusterProbe{"prod": {unreachable}})
+	if got, want := probeMetricSeriesOf(t, "kubebrowser_cluster_up"), []string{"[prod prod]"}; !slices.Equal(got, want) {
+		t.Errorf("cluster_up series = %q, want %q", got, want)
+	}
+	for _, name := range []string{"kubebrowser_cluster_info", "kubebrowser_cluster_ready", "kubebrowser_cluster_probe_duration_seconds"} {
+		if got := probeMetricSeriesOf(t, name); len(got) != 0 {
+			t.Errorf("%s series = %q, want none", name, got)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+)
+
+// Reconciles the status of Kubeconfigs: validates them and records the last probes of their API
+// servers
+type Reconciler struct {
+	informer cache.SharedIndexInformer
+	mu       sync.Mutex
+	// Set while the reconciler runs, a queue cannot be reused once shut down
+	queue workqueue.TypedRateLimitingInterface[string]
+}
+
+func NewReconciler(informer cache.SharedIndexInformer) (*Reconciler, error) {
+	r := &Reconciler{informer: informer}
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.enqueue,
+		UpdateFunc: func(_, obj any) { r.enqueue(obj) },
+	})
+	return r, err
+}
+
+func (r *Reconciler) enqueue(obj any) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Every Kubeconfig is enqueued when the reconciler starts
+	if r.queue != nil {
+		r.queue.Add(key)
+	}
+}
+
+// Runs workers until the context is cancelled. The reconciler can run again afterwards, when the
+// leadership is acquired again.
+func (r *Reconciler) Run(ctx context.Context, workers int) {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[string](),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "kubeconfigs"},
+	)
+	r.mu.Lock()
+	r.queue = queue
+	r.mu.Unlock()
+	for _, key := range r.informer.GetStore().ListKeys() {
+		queue.Add(key)
+	}
+
+	logger.Info("Starting kubeconfig reconciler")
+	for range workers {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) { r.runWorker(ctx, queue) }, time.Second)
+	}
+	<-ctx.Done()
+	logger.Info("Stopping kubeconfig reconciler")
+
+	r.mu.Lock()
+	r.queue = nil
+	r.mu.Unlock()
+	queue.ShutDown()
+}
+
+func (r *Reconciler) runWorker(ctx context.Context, queue workqueue.TypedRateLimitingInterface[string]) {
+	for r.processNextItem(ctx, queue) {
+	}
+}
+
+func (r *Reconciler) processNextItem(ctx context.Context, queue workqueue.TypedRateLimitingInterface[string]) bool {
+	key, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+	defer queue.Done(key)
+
+	if err := r.reconcile(ctx, key); err != nil {
+		logger.Errorf("Error reconciling kubeconfig %s: %s", key, err)
+		queue.AddRateLimited(key)
+		return true
+	}
+	queue.Forget(key)
+	return true
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	if !watchedNamespace(namespace) {
+		return nil
+	}
+
+	kubeconfig, err := kubecfg.lister.Kubeconfigs(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	status := kubeconfig.Status.DeepCopy()
+	status.ObservedGeneration = kubeconfig.Generation
+
+	resolved, err := resolveKubeconfig(kubeconfig)
+	if err != nil {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SourceUnavailable",
+			Message:            err.Error(),
+			ObservedGeneration: kubeconfig.Generation,
+		})
+		meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionReachable)
+		status.Clusters = nil
+	} else if errs := validateKubeconfigSpec(&resolved.Spec); len(errs) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ValidationFailed",
+			Message:            errs.ToAggregate().Error(),
+			ObservedGeneration: kubeconfig.Generation,
+		})
+		meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionReachable)
+		status.Clusters = nil
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionValid,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Validated",
+			ObservedGeneration: kubeconfig.Generation,
+		})
+		if clusters, reachable, ok := reachableCondition(resolved, clusterProbes.get(kubeconfigID(kubeconfig))); ok {
+			status.Clusters = clusters
+			meta.SetStatusCondition(&status.Conditions, reachable)
+		} else if viper.GetDuration(probeIntervalKey) <= 0 {
+			meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionReachable)
+			status.Clusters = nil
+		}
+	}
+
+	if equality.Semantic.DeepEqual(&kubeconfig.Status, status) {
+		return nil
+	}
+
+	updated := kubeconfig.DeepCopy()
+	updated.Status = *status
+	_, err = kubecfg.client.KubeconfigV1alpha1().Kubeconfigs(namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// Returns the statuses of the clusters of the Kubeconfig from their last probes, and whether they
+// are reachable and trusted. Returns false until every cluster has been probed.
+func reachableCondition(kubeconfig *v1alpha1.Kubeconfig, probes []ClusterProbe) ([]v1alpha1.ClusterStatus, metav1.Condition, bool) {
+	var statuses []v1alpha1.ClusterStatus
+	var unreachable, untrusted []string
+	for _, cluster := range kubeconfig.Spec.Kubeconfig.Clusters {
+		i := slices.IndexFunc(probes, func(probe ClusterProbe) bool { return probe.Name == cluster.Name })
+		if i < 0 {
+			return nil, metav1.Condition{}, false
+		}
+		status := probes[i].ClusterStatus
+		statuses = append(statuses, status)
+		if !status.Reachable {
+			unreachable = append(unreachable, fmt.Sprintf("%s: %s", cluster.Name, status.Message))
+		} else if !cluster.Cluster.InsecureSkipTLSVerify && (!ptr.Deref(status.CertificateValid, true) || !ptr.Deref(status.CertificateAuthorityValid, true)) {
+			untrusted = append(untrusted, fmt.Sprintf("%s: %s", cluster.Name, status.Message))
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reachable",
+		ObservedGeneration: kubeconfig.Generation,
+	}
+	if len(unreachable) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Unreachable"
+		condition.Message = strings.Join(append(unreachable, untrusted...), "; ")
+	} else if len(untrusted) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CertificateInvalid"
+		condition.Message = strings.Join(untrusted, "; ")
+	}
+	return statuses, condition, true
+}
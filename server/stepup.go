@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const stepUpRoute = "/stepup/"
+
+// Claims describing how and when the user authenticated
+type AuthClaims struct {
+	AuthTime int64  `json:"auth_time"`
+	ACR      string `json:"acr"`
+}
+
+// Returns true if the authentication of the user is strong and recent enough for the Kubeconfig
+func stepUpSatisfied(spec *v1alpha1.KubeconfigSpec, claims AuthClaims, now time.Time) bool {
+	if spec.RequiredACR != "" && claims.ACR != spec.RequiredACR {
+		return false
+	}
+	if spec.RequireAuthAge != nil {
+		if claims.AuthTime == 0 {
+			return false
+		}
+		if now.Sub(time.Unix(claims.AuthTime, 0)) > spec.RequireAuthAge.Duration {
+			return false
+		}
+	}
+	return true
+}
+
+// Triggers a new login satisfying the requirements of a Kubeconfig, then returns to it in the UI
+func handleStepUp(c *gin.Context) {
+	logger.Debug("Entering handleStepUp")
+
+	id := strings.TrimPrefix(c.Param("id"), "/")
+	kubeconfig, err := authorizedKubeconfig(c, id)
+	if apierrors.IsNotFound(err) {
+		c.String(http.StatusNotFound, "Kubeconfig not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting kubeconfig: %s", err)
+		c.String(http.StatusInternalServerError, "Error getting kubeconfig")
+		return
+	}
+
+	var claims AuthClaims
+	if err := sessionClaims(c, &claims); err != nil {
+		logger.Errorf("Error extracting claims: %s", err)
+		c.String(http.StatusInternalServerError, "Error extracting claims")
+		return
+	}
+
+	session := sessions.Default(c)
+	if stepUpSatisfied(&kubeconfig.Spec, claims, time.Now()) {
+		session.Delete(stepUpKey)
+		if err := session.Save(); err != nil {
+			logger.Errorf("Cannot save session: %s", err)
+		}
+		c.Redirect(http.StatusFound, "/home/#"+id)
+		return
+	}
+
+	// The identity provider already had a chance to satisfy the requirements, avoid a login loop
+	if session.Get(stepUpKey) == id {
+		session.Delete(stepUpKey)
+		if err := session.Save(); err != nil {
+			logger.Errorf("Cannot save session: %s", err)
+		}
+		audit("Step-up authentication failed", "kubeconfig", id, "acr", claims.ACR)
+		c.String(http.StatusForbidden, "Your login does not satisfy the requirements of this kubeconfig")
+		return
+	}
+
+	session.Set(stepUpKey, id)
+	if err := session.Save(); err != nil {
+		logger.Errorf("Cannot save session: %s", err)
+		c.String(http.StatusInternalServerError, "Cannot save session")
+		return
+	}
+
+	var opts []oauth2.AuthCodeOption
+	if kubeconfig.Spec.RequireAuthAge != nil {
+		maxAge := int(kubeconfig.Spec.RequireAuthAge.Duration.Seconds())
+		opts = append(opts, oauth2.SetAuthURLParam("max_age", strconv.Itoa(maxAge)))
+	}
+	if kubeconfig.Spec.RequiredACR != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("acr_values", kubeconfig.Spec.RequiredACR))
+	}
+	logger.Infow("Step-up authentication required", "kubeconfig", id)
+	redirectToOIDCLogin(c, opts...)
+}
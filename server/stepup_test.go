@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStepUpSatisfied(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		requireAuthAge *metav1.Duration
+		requiredACR    string
+		claims         AuthClaims
+		want           bool
+	}{
+		{name: "no requirement", claims: AuthClaims{}, want: true},
+		{name: "recent login", requireAuthAge: &metav1.Duration{Duration: 5 * time.Minute}, claims: AuthClaims{AuthTime: now.Add(-time.Minute).Unix()}, want: true},
+		{name: "old login", requireAuthAge: &metav1.Duration{Duration: 5 * time.Minute}, claims: AuthClaims{AuthTime: now.Add(-time.Hour).Unix()}, want: false},
+		{name: "no auth_time", requireAuthAge: &metav1.Duration{Duration: 5 * time.Minute}, claims: AuthClaims{}, want: false},
+		{name: "matching acr", requiredACR: "mfa", claims: AuthClaims{ACR: "mfa"}, want: true},
+		{name: "other acr", requiredACR: "mfa", claims: AuthClaims{ACR: "pwd"}, want: false},
+		{name: "no acr", requiredACR: "mfa", claims: AuthClaims{}, want: false},
+		{name: "matching acr of an old login", requireAuthAge: &metav1.Duration{Duration: 5 * time.Minute}, requiredACR: "mfa", claims: AuthClaims{ACR: "mfa", AuthTime: now.Add(-time.Hour).Unix()}, want: false},
+		{name: "recent login with both", requireAuthAge: &metav1.Duration{Duration: 5 * time.Minute}, requiredACR: "mfa", claims: AuthClaims{ACR: "mfa", AuthTime: now.Unix()}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testKubeconfigSpec("prod")
+			spec.RequireAuthAge, spec.RequiredACR = tt.requireAuthAge, tt.requiredACR
+
+			if got := stepUpSatisfied(&spec, tt.claims, now); got != tt.want {
+				t.Errorf("stepUpSatisfied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVisibleKubeconfig(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	previousCatalog := kubecfg.catalog
+	t.Cleanup(func() {
+		viper.Set(podNamespaceKey, "")
+		kubecfg.catalog = previousCatalog
+	})
+	kubecfg.catalog = kubernetesCatalog{}
+
+	kubeconfig := func(name string, whitelist *v1alpha1.Whitelist, lifecycle *v1alpha1.Lifecycle) *v1alpha1.Kubeconfig {
+		spec := testKubeconfigSpec(name)
+		spec.Whitelist, spec.Lifecycle = whitelist, lifecycle
+		return &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: name}, Spec: spec}
+	}
+	setTestListers(t, []*v1alpha1.Kubeconfig{
+		kubeconfig("public", nil, nil),
+		kubeconfig("prod", &v1alpha1.Whitelist{Users: []string{"bob@example.com"}}, nil),
+		kubeconfig("admin", &v1alpha1.Whitelist{Groups: []string{"admins"}}, nil),
+		kubeconfig("retired", nil, &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDisabled}),
+	}, nil)
+	setTestAccessClient(t, nil, []*v1alpha1.AccessGrant{testAccessGrant("prod-grant", nil, time.Now().Add(time.Hour))})
+
+	tests := []struct {
+		name      string
+		id        string
+		claims    EmailAndGroups
+		wantFound bool
+	}{
+		{name: "public", id: "public", claims: EmailAndGroups{Email: "alice@example.com"}, wantFound: true},
+		{name: "whitelisted group", id: "admin", claims: EmailAndGroups{Email: "alice@example.com", Groups: []string{"admins"}}, wantFound: true},
+		{name: "hidden", id: "admin", claims: EmailAndGroups{Email: "alice@example.com"}},
+		{name: "granted", id: "prod", claims: EmailAndGroups{Email: "jane@example.com"}, wantFound: true},
+		{name: "not granted", id: "prod", claims: EmailAndGroups{Email: "alice@example.com"}},
+		{name: "disabled", id: "retired", claims: EmailAndGroups{Email: "alice@example.com"}},
+		{name: "unknown", id: "unknown", claims: EmailAndGroups{Email: "alice@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfig, err := visibleKubeconfig(tt.id, tt.claims)
+			if !tt.wantFound {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("visibleKubeconfig() error = %v, want NotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("visibleKubeconfig() error = %v", err)
+			}
+			if kubeconfig.Name != tt.id {
+				t.Errorf("visibleKubeconfig() = %s, want %s", kubeconfig.Name, tt.id)
+			}
+		})
+	}
+}
@@ -3,10 +3,15 @@ package main
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"slices"
+	"time"
 
 	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	v1beta1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1beta1"
 	"github.com/spf13/viper"
 )
 
@@ -18,10 +23,11 @@ func randString(nByte int) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
-// Returns a subset of initial Kubeconfigs depending on the whitelist in each Kubeconfig and the
-// claims (user and groups) in the idToken
-func filterKubeConfigs(kubeconfigs []*v1alpha1.Kubeconfig, claims EmailAndGroups) []*v1alpha1.Kubeconfig {
+// Returns a subset of initial Kubeconfigs depending on the whitelist in each Kubeconfig, the
+// claims (user and groups) in the idToken and the active grants of the user
+func filterKubeConfigs(kubeconfigs []*v1alpha1.Kubeconfig, claims EmailAndGroups, grants []*v1alpha1.AccessGrant) []*v1alpha1.Kubeconfig {
 	logger.Debug("Entering filterKubeconfig")
+	granted := grantedKubeconfigs(grants, claims.Email)
 	filtered := make([]*v1alpha1.Kubeconfig, 0, len(kubeconfigs))
 	for _, kubeconfig := range kubeconfigs {
 		whitelist := kubeconfig.Spec.Whitelist
@@ -33,28 +39,82 @@ func filterKubeConfigs(kubeconfigs []*v1alpha1.Kubeconfig, claims EmailAndGroups
 			continue
 		}
 
-		// Check if user email is in whitelist
-		if slices.Contains(whitelist.Users, claims.Email) {
-			logger.Debugw("User match found, adding kubeconfig", "name", kubeconfig.Name, "email", claims.Email)
+		if matchWhitelist(whitelist, claims) {
+			logger.Debugw("Whitelist match found, adding kubeconfig", "name", kubeconfig.Name, "email", claims.Email)
 			filtered = append(filtered, kubeconfig)
 			continue
 		}
 
-		// Check if any group matches the whitelist
-		for _, group := range claims.Groups {
-			if slices.Contains(whitelist.Groups, group) {
-				logger.Debugw("Group match found, adding kubeconfig", "name", kubeconfig.Name, "group", group)
-				filtered = append(filtered, kubeconfig)
-				break
-			}
+		if granted[kubeconfigID(kubeconfig)] {
+			logger.Debugw("Active grant found, adding kubeconfig", "name", kubeconfig.Name, "email", claims.Email)
+			filtered = append(filtered, kubeconfig)
 		}
 	}
 	return filtered
 }
 
-func kubeConfigUser(rawIDToken, refreshToken string) v1alpha1.User {
+// Returns true if the user email or any of its groups is in the whitelist
+func matchWhitelist(whitelist *v1alpha1.Whitelist, claims EmailAndGroups) bool {
+	if whitelist == nil {
+		return false
+	}
+
+	// Check if user email is in whitelist
+	if slices.Contains(whitelist.Users, claims.Email) {
+		return true
+	}
+
+	// Check if the user is a member of any group of the whitelist
+	return slices.ContainsFunc(whitelist.Groups, claims.memberOf)
+}
+
+// Returns the set of Kubeconfig IDs the user has a non expired grant on
+func grantedKubeconfigs(grants []*v1alpha1.AccessGrant, email string) map[string]bool {
+	granted := make(map[string]bool)
+	now := time.Now()
+	for _, grant := range grants {
+		if grant.Spec.User == email && grant.Spec.ExpiresAt.After(now) {
+			granted[grant.Spec.Kubeconfig] = true
+		}
+	}
+	return granted
+}
+
+// Returns how the user credentials of a Kubeconfig must be rendered
+func credentialMode(kubeconfig *v1alpha1.Kubeconfig) v1beta1.CredentialMode {
+	var credentials v1beta1.Credentials
+	if raw, ok := kubeconfig.Annotations[v1beta1.CredentialsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &credentials); err != nil {
+			logger.Warnw("Invalid credentials annotation, using auth-provider", "name", kubeconfig.Name, "error", err)
+		}
+	}
+	return credentials.Mode
+}
+
+func kubeConfigUser(mode v1beta1.CredentialMode, rawIDToken, refreshToken string) v1alpha1.User {
+	if mode == v1beta1.CredentialModeExec {
+		// kubelogin runs its own login flow, no token is handed out
+		args := []string{
+			"oidc-login",
+			"get-token",
+			"--oidc-issuer-url=" + viper.GetString("oauth2_issuer_url"),
+			"--oidc-client-id=" + oauth2Config.ClientID,
+		}
+		if oauth2Config.ClientSecret != "" {
+			args = append(args, "--oidc-client-secret="+oauth2Config.ClientSecret)
+		}
+		return v1alpha1.User{Name: "oidc", User: v1alpha1.UserSpec{
+			Exec: &v1alpha1.ExecConfig{
+				APIVersion:      "client.authentication.k8s.io/v1beta1",
+				Command:         "kubectl",
+				Args:            args,
+				InteractiveMode: "IfAvailable",
+			},
+		}}
+	}
+
 	return v1alpha1.User{Name: "oidc", User: v1alpha1.UserSpec{
-		AuthProvider: v1alpha1.AuthProviderSpec{Name: "oidc", Config: v1alpha1.AuthProviderConfig{
+		AuthProvider: &v1alpha1.AuthProviderSpec{Name: "oidc", Config: v1alpha1.AuthProviderConfig{
 			ClientID:     oauth2Config.ClientID,
 			ClientSecret: oauth2Config.ClientSecret,
 			IDPIssuerURL: viper.GetString("oauth2_issuer_url"),
@@ -64,17 +124,85 @@ func kubeConfigUser(rawIDToken, refreshToken string) v1alpha1.User {
 	}}
 }
 
-func toKubeConfigSpecs(filteredKubeconfigs []*v1alpha1.Kubeconfig, user v1alpha1.User) []*v1alpha1.KubeconfigSpec {
-	copiedKubeconfig := make([]*v1alpha1.KubeconfigSpec, 0, len(filteredKubeconfigs))
+// KubeconfigView is a rendered Kubeconfig as sent to the UI
+type KubeconfigView struct {
+	*v1alpha1.KubeconfigSpec
+	// Name of the Kubeconfig resource, prefixed by its namespace outside of the pod namespace
+	ID string `json:"id"`
+	// Namespace of the Kubeconfig, empty for ClusterKubeconfigs
+	Namespace string `json:"namespace,omitempty"`
+	// Team owning the namespace of the Kubeconfig
+	Team string `json:"team,omitempty"`
+	// Source cluster of the Kubeconfig in the federated catalog
+	Source string `json:"source,omitempty"`
+	// Set when the source cluster cannot be reached and the Kubeconfig may be outdated
+	Stale bool `json:"stale,omitempty"`
+	// Set when the user must log in again to get credentials for this Kubeconfig
+	StepUpURL string `json:"stepUpURL,omitempty"`
+	// Warnings to show the user, such as the deprecation of the Kubeconfig
+	Warnings []string `json:"warnings,omitempty"`
+	// Last probes of the clusters of the Kubeconfig
+	Probes []ClusterProbe `json:"probes,omitempty"`
+	// Expiry of the certificate authority data of the clusters of the Kubeconfig
+	CertificateAuthorities []CertificateAuthorityExpiry `json:"certificateAuthorities,omitempty"`
+}
+
+func toKubeConfigViews(filteredKubeconfigs []*v1alpha1.Kubeconfig, rawIDToken, refreshToken string) []KubeconfigView {
+	copiedKubeconfig := make([]KubeconfigView, 0, len(filteredKubeconfigs))
 	for _, kubeconfig := range filteredKubeconfigs {
-		k := kubeconfig.DeepCopy()
-		ks := k.Spec
-		ks.Whitelist = nil                                      // Remove whitelist information
-		ks.Kubeconfig.Users = nil                               // Remove all users
-		ks.Kubeconfig.Users = append(ks.Kubeconfig.Users, user) // Put user created before
-		ks.Kubeconfig.Contexts = ks.Kubeconfig.Contexts[:1]     // Keep first context only
-		ks.Kubeconfig.Contexts[0].Context.User = user.Name      // Put same name as user
-		copiedKubeconfig = append(copiedKubeconfig, &ks)
+		if kubeconfigDisabled(kubeconfig) {
+			continue
+		}
+		ks, err := renderKubeconfigSpec(kubeconfig, kubeConfigUser(credentialMode(kubeconfig), rawIDToken, refreshToken))
+		if err != nil {
+			logger.Warnw("Skipping kubeconfig that cannot be rendered", "name", kubeconfig.Name, "error", err)
+			continue
+		}
+		copiedKubeconfig = append(copiedKubeconfig, KubeconfigView{
+			KubeconfigSpec:         ks,
+			ID:                     kubeconfigID(kubeconfig),
+			Namespace:              kubeconfig.Namespace,
+			Team:                   kubeconfigTeam(kubeconfig),
+			Source:                 kubeconfigSource(kubeconfig),
+			Stale:                  kubeconfig.Annotations[staleAnnotation] == "true",
+			Warnings:               kubeconfigWarnings(kubeconfig),
+			Probes:                 clusterProbes.get(kubeconfigID(kubeconfig)),
+			CertificateAuthorities: certificateAuthorityExpiries(kubeconfig),
+		})
 	}
 	return copiedKubeconfig
 }
+
+// Returns a copy of the spec of a Kubeconfig with its first context only, using the user, or an
+// error if client-go would reject it
+func renderKubeconfigSpec(kubeconfig *v1alpha1.Kubeconfig, user v1alpha1.User) (*v1alpha1.KubeconfigSpec, error) {
+	if len(kubeconfig.Spec.Kubeconfig.Contexts) == 0 {
+		return nil, errors.New("no context")
+	}
+	k := kubeconfig.DeepCopy()
+	ks := k.Spec
+	ks.Whitelist = nil                                      // Remove whitelist information
+	ks.Approvers = nil                                      // Remove approvers information
+	ks.Kubeconfig.Users = nil                               // Remove all users
+	ks.Kubeconfig.Users = append(ks.Kubeconfig.Users, user) // Put user created before
+	ks.Kubeconfig.Contexts = ks.Kubeconfig.Contexts[:1]     // Keep first context only
+	ks.Kubeconfig.Contexts[0].Context.User = user.Name      // Put same name as user
+	ks.Kubeconfig.CurrentContext = ks.Kubeconfig.Contexts[0].Name
+	if err := validateRendered(&ks.Kubeconfig); err != nil {
+		return nil, fmt.Errorf("rejected by client-go: %w", err)
+	}
+	return &ks, nil
+}
+
+// Returns whether a Kubeconfig is served to its users, that is enabled and renderable. Tokens do
+// not change whether a Kubeconfig can be rendered, so it is rendered without them.
+func servedKubeconfig(kubeconfig *v1alpha1.Kubeconfig) bool {
+	if kubeconfigDisabled(kubeconfig) {
+		return false
+	}
+	if _, err := renderKubeconfigSpec(kubeconfig, kubeConfigUser(credentialMode(kubeconfig), "", "")); err != nil {
+		logger.Warnw("Skipping kubeconfig that cannot be rendered", "name", kubeconfig.Name, "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/url"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Checks that a Kubeconfig can be rendered to users
+func validateKubeconfigSpec(spec *v1alpha1.KubeconfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	kubeconfigPath := field.NewPath("spec", "kubeconfig")
+
+	if spec.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "name"), "display name is required"))
+	}
+
+	for i, link := range spec.Links {
+		linkPath := field.NewPath("spec", "links").Index(i)
+		if link.Name == "" {
+			allErrs = append(allErrs, field.Required(linkPath.Child("name"), ""))
+		}
+		if u, err := url.Parse(link.URL); err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			allErrs = append(allErrs, field.Invalid(linkPath.Child("url"), link.URL, "must be an absolute http or https URL"))
+		}
+	}
+
+	if spec.Lifecycle != nil {
+		switch spec.Lifecycle.State {
+		case "", v1alpha1.LifecycleActive, v1alpha1.LifecycleMaintenance, v1alpha1.LifecycleDeprecated, v1alpha1.LifecycleDisabled:
+		default:
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "lifecycle", "state"), spec.Lifecycle.State, []v1alpha1.LifecycleState{
+				v1alpha1.LifecycleActive, v1alpha1.LifecycleMaintenance, v1alpha1.LifecycleDeprecated, v1alpha1.LifecycleDisabled,
+			}))
+		}
+	}
+
+	clusters := make(map[string]bool, len(spec.Kubeconfig.Clusters))
+	clustersPath := kubeconfigPath.Child("clusters")
+	if len(spec.Kubeconfig.Clusters) == 0 {
+		allErrs = append(allErrs, field.Required(clustersPath, "at least one cluster is required"))
+	}
+	for i, cluster := range spec.Kubeconfig.Clusters {
+		clusterPath := clustersPath.Index(i)
+		if cluster.Name == "" {
+			allErrs = append(allErrs, field.Required(clusterPath.Child("name"), ""))
+		}
+		if clusters[cluster.Name] {
+			allErrs = append(allErrs, field.Duplicate(clusterPath.Child("name"), cluster.Name))
+		}
+		clusters[cluster.Name] = true
+
+		detailsPath := clusterPath.Child("cluster")
+		server, err := url.Parse(cluster.Cluster.Server)
+		if cluster.Cluster.Server == "" {
+			allErrs = append(allErrs, field.Required(detailsPath.Child("server"), ""))
+		} else if err != nil || server.Host == "" {
+			allErrs = append(allErrs, field.Invalid(detailsPath.Child("server"), cluster.Cluster.Server, "must be an absolute URL"))
+		} else if server.Scheme != "https" && !cluster.Cluster.InsecureSkipTLSVerify {
+			allErrs = append(allErrs, field.Invalid(detailsPath.Child("server"), cluster.Cluster.Server, "must use https unless insecure-skip-tls-verify is set"))
+		}
+
+		if cluster.Cluster.ProxyURL != "" {
+			if proxy, err := url.Parse(cluster.Cluster.ProxyURL); err != nil || proxy.Host == "" {
+				allErrs = append(allErrs, field.Invalid(detailsPath.Child("proxy-url"), cluster.Cluster.ProxyURL, "must be an absolute URL"))
+			}
+		}
+
+		if cluster.Cluster.CertificateAuthorityData != "" {
+			if _, err := parseCertificateAuthorityData(cluster.Cluster.CertificateAuthorityData); err != nil {
+				allErrs = append(allErrs, field.Invalid(detailsPath.Child("certificate-authority-data"), "<redacted>", err.Error()))
+			}
+		}
+	}
+
+	contextsPath := kubeconfigPath.Child("contexts")
+	if len(spec.Kubeconfig.Contexts) == 0 {
+		allErrs = append(allErrs, field.Required(contextsPath, "at least one context is required"))
+	}
+	for i, context := range spec.Kubeconfig.Contexts {
+		contextPath := contextsPath.Index(i)
+		if context.Name == "" {
+			allErrs = append(allErrs, field.Required(contextPath.Child("name"), ""))
+		}
+		if !clusters[context.Context.Cluster] {
+			allErrs = append(allErrs, field.NotFound(contextPath.Child("context", "cluster"), context.Context.Cluster))
+		}
+	}
+
+	return allErrs
+}
+
+// Fields of a kubeconfig user holding static credentials
+var staticCredentialFields = []string{
+	"token",
+	"tokenFile",
+	"username",
+	"password",
+	"client-certificate",
+	"client-certificate-data",
+	"client-key",
+	"client-key-data",
+}
+
+// Checks that users embedded in a raw Kubeconfig object hold no static credentials. Unknown
+// fields are dropped when decoding into the API types, so this works on the raw object.
+func validateNoStaticCredentials(raw []byte) field.ErrorList {
+	var object struct {
+		Spec struct {
+			Kubeconfig struct {
+				Users []struct {
+					User map[string]json.RawMessage `json:"user"`
+				} `json:"users"`
+			} `json:"kubeconfig"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	var allErrs field.ErrorList
+	usersPath := field.NewPath("spec", "kubeconfig", "users")
+	for i, user := range object.Spec.Kubeconfig.Users {
+		for _, name := range staticCredentialFields {
+			if _, ok := user.User[name]; ok {
+				allErrs = append(allErrs, field.Forbidden(usersPath.Index(i).Child("user", name), "static credentials must not be stored in a Kubeconfig"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// Checks that no other Kubeconfig or ClusterKubeconfig of the cluster, whatever its namespace,
+// has the same display name. ClusterKubeconfigs have no namespace.
+func validateUniqueDisplayName(kubeconfig *v1alpha1.Kubeconfig, others []*v1alpha1.Kubeconfig) field.ErrorList {
+	for _, other := range others {
+		if sameCatalogEntry(kubeconfig, other) {
+			continue
+		}
+		if other.Spec.Name == kubeconfig.Spec.Name {
+			return field.ErrorList{field.Duplicate(field.NewPath("spec", "name"), kubeconfig.Spec.Name)}
+		}
+	}
+	return nil
+}
+
+// Returns true if both Kubeconfigs are the same object, or a ClusterKubeconfig and the Kubeconfig
+// of the pod namespace hiding it
+func sameCatalogEntry(a, b *v1alpha1.Kubeconfig) bool {
+	if a.Name != b.Name {
+		return false
+	}
+	if a.Namespace == b.Namespace {
+		return true
+	}
+	podNamespace := viper.GetString(podNamespaceKey)
+	return (a.Namespace == "" && b.Namespace == podNamespace) || (a.Namespace == podNamespace && b.Namespace == "")
+}
+
+// Decodes base64 encoded PEM certificates
+func parseCertificateAuthorityData(data string) ([]*x509.Certificate, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, errors.New("must be base64 encoded")
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, raw = pem.Decode(raw)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.New("contains an invalid certificate: " + err.Error())
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("must contain at least one PEM encoded certificate")
+	}
+	return certs, nil
+}
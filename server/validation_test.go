@@ -0,0 +1,283 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Returns base64 encoded PEM data holding a self-signed CA certificate valid until notAfter
+func testCertificateAuthorityData(t *testing.T, commonName string, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// Returns a valid Kubeconfig spec with a single cluster
+func testKubeconfigSpec(name string) v1alpha1.KubeconfigSpec {
+	return v1alpha1.KubeconfigSpec{
+		Name: name,
+		Kubeconfig: v1alpha1.KubeconfigData{
+			APIVersion: "v1",
+			Kind:       "Config",
+			Clusters: []v1alpha1.Cluster{
+				{Name: "prod", Cluster: v1alpha1.Details{Server: "https://prod.example.com:6443"}},
+			},
+			Contexts: []v1alpha1.Context{
+				{Name: "prod", Context: v1alpha1.ContextSpec{Cluster: "prod", User: "oidc"}},
+			},
+		},
+	}
+}
+
+// Returns the type and path of each error, to compare them without their message
+func errorFields(errs field.ErrorList) []string {
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, string(err.Type)+" "+err.Field)
+	}
+	return fields
+}
+
+func TestValidateKubeconfigSpec(t *testing.T) {
+	validCA := testCertificateAuthorityData(t, "prod-ca", time.Now().Add(24*time.Hour))
+
+	tests := []struct {
+		name   string
+		mutate func(spec *v1alpha1.KubeconfigSpec)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {},
+			want:   []string{},
+		},
+		{
+			name: "valid with metadata and certificate authority",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Links = []v1alpha1.Link{{Name: "Runbook", URL: "https://wiki.example.com/prod"}}
+				spec.Lifecycle = &v1alpha1.Lifecycle{State: v1alpha1.LifecycleDeprecated}
+				spec.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData = validCA
+				spec.Kubeconfig.Clusters[0].Cluster.ProxyURL = "socks5://proxy.example.com:1080"
+			},
+			want: []string{},
+		},
+		{
+			name:   "missing display name",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) { spec.Name = "" },
+			want:   []string{"FieldValueRequired spec.name"},
+		},
+		{
+			name: "invalid links",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Links = []v1alpha1.Link{{URL: "https://wiki.example.com"}, {Name: "Dashboard", URL: "javascript:alert(1)"}}
+			},
+			want: []string{"FieldValueRequired spec.links[0].name", "FieldValueInvalid spec.links[1].url"},
+		},
+		{
+			name: "unsupported lifecycle state",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Lifecycle = &v1alpha1.Lifecycle{State: "retired"}
+			},
+			want: []string{"FieldValueNotSupported spec.lifecycle.state"},
+		},
+		{
+			name: "no clusters",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters = nil
+			},
+			want: []string{"FieldValueRequired spec.kubeconfig.clusters", "FieldValueNotFound spec.kubeconfig.contexts[0].context.cluster"},
+		},
+		{
+			name: "duplicate cluster",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters = append(spec.Kubeconfig.Clusters, spec.Kubeconfig.Clusters[0])
+			},
+			want: []string{"FieldValueDuplicate spec.kubeconfig.clusters[1].name"},
+		},
+		{
+			name: "missing server",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.Server = ""
+			},
+			want: []string{"FieldValueRequired spec.kubeconfig.clusters[0].cluster.server"},
+		},
+		{
+			name: "relative server",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.Server = "prod.example.com:6443"
+			},
+			want: []string{"FieldValueInvalid spec.kubeconfig.clusters[0].cluster.server"},
+		},
+		{
+			name: "plain http server",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.Server = "http://prod.example.com:8080"
+			},
+			want: []string{"FieldValueInvalid spec.kubeconfig.clusters[0].cluster.server"},
+		},
+		{
+			name: "plain http server with insecure-skip-tls-verify",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.Server = "http://prod.example.com:8080"
+				spec.Kubeconfig.Clusters[0].Cluster.InsecureSkipTLSVerify = true
+			},
+			want: []string{},
+		},
+		{
+			name: "relative proxy",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.ProxyURL = "proxy.example.com"
+			},
+			want: []string{"FieldValueInvalid spec.kubeconfig.clusters[0].cluster.proxy-url"},
+		},
+		{
+			name: "certificate authority not base64",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData = "not base64!"
+			},
+			want: []string{"FieldValueInvalid spec.kubeconfig.clusters[0].cluster.certificate-authority-data"},
+		},
+		{
+			name: "certificate authority without certificate",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData = base64.StdEncoding.EncodeToString([]byte("hello"))
+			},
+			want: []string{"FieldValueInvalid spec.kubeconfig.clusters[0].cluster.certificate-authority-data"},
+		},
+		{
+			name: "no contexts",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Contexts = nil
+			},
+			want: []string{"FieldValueRequired spec.kubeconfig.contexts"},
+		},
+		{
+			name: "context of an unknown cluster",
+			mutate: func(spec *v1alpha1.KubeconfigSpec) {
+				spec.Kubeconfig.Contexts[0].Name = ""
+				spec.Kubeconfig.Contexts[0].Context.Cluster = "staging"
+			},
+			want: []string{"FieldValueRequired spec.kubeconfig.contexts[0].name", "FieldValueNotFound spec.kubeconfig.contexts[0].context.cluster"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testKubeconfigSpec("Production")
+			tt.mutate(&spec)
+			got := errorFields(validateKubeconfigSpec(&spec))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateKubeconfigSpec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNoStaticCredentials(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{
+			name: "no users",
+			raw:  `{"spec":{"kubeconfig":{}}}`,
+			want: []string{},
+		},
+		{
+			name: "oidc user",
+			raw:  `{"spec":{"kubeconfig":{"users":[{"name":"oidc","user":{"auth-provider":{"name":"oidc"}}}]}}}`,
+			want: []string{},
+		},
+		{
+			name: "token and client key",
+			raw:  `{"spec":{"kubeconfig":{"users":[{"name":"oidc"},{"name":"admin","user":{"token":"abc","client-key-data":"def"}}]}}}`,
+			want: []string{"FieldValueForbidden spec.kubeconfig.users[1].user.token", "FieldValueForbidden spec.kubeconfig.users[1].user.client-key-data"},
+		},
+		{
+			name: "invalid object",
+			raw:  `[]`,
+			want: []string{"InternalError spec"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorFields(validateNoStaticCredentials([]byte(tt.raw)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateNoStaticCredentials() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUniqueDisplayName(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	t.Cleanup(func() { viper.Set(podNamespaceKey, "") })
+
+	kubeconfig := func(namespace, name, displayName string) *v1alpha1.Kubeconfig {
+		return &v1alpha1.Kubeconfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1alpha1.KubeconfigSpec{Name: displayName},
+		}
+	}
+	others := []*v1alpha1.Kubeconfig{
+		kubeconfig("kubebrowser", "prod", "Production"),
+		kubeconfig("team-a", "staging", "Staging"),
+		kubeconfig("", "shared", "Shared"),
+	}
+
+	tests := []struct {
+		name       string
+		kubeconfig *v1alpha1.Kubeconfig
+		want       []string
+	}{
+		{name: "new display name", kubeconfig: kubeconfig("kubebrowser", "dev", "Development"), want: []string{}},
+		{name: "update of the same object", kubeconfig: kubeconfig("kubebrowser", "prod", "Production"), want: []string{}},
+		{name: "same name in another namespace", kubeconfig: kubeconfig("team-b", "prod", "Production"), want: []string{"FieldValueDuplicate spec.name"}},
+		{name: "display name of another namespace", kubeconfig: kubeconfig("kubebrowser", "stg", "Staging"), want: []string{"FieldValueDuplicate spec.name"}},
+		{name: "display name of a ClusterKubeconfig", kubeconfig: kubeconfig("team-a", "shared", "Shared"), want: []string{"FieldValueDuplicate spec.name"}},
+		{name: "Kubeconfig hiding a ClusterKubeconfig", kubeconfig: kubeconfig("kubebrowser", "shared", "Shared"), want: []string{}},
+		{name: "ClusterKubeconfig hidden by a Kubeconfig", kubeconfig: kubeconfig("", "prod", "Production"), want: []string{}},
+		{name: "ClusterKubeconfig with a used display name", kubeconfig: kubeconfig("", "staging", "Staging"), want: []string{"FieldValueDuplicate spec.name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorFields(validateUniqueDisplayName(tt.kubeconfig, others))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateUniqueDisplayName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	ginzap "github.com/gin-contrib/zap"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const validateKubeconfigRoute = "/validate-kubeconfig"
+
+// Serves the validating admission and conversion webhooks over TLS until the context is cancelled
+func runWebhookServer(ctx context.Context) {
+	router := gin.New()
+	router.Use(ginzap.Ginzap(logger.Desugar(), time.RFC3339, true))
+	router.POST(validateKubeconfigRoute, handleValidateKubeconfig)
+	router.POST(convertKubeconfigRoute, handleConvertKubeconfig)
+
+	srv := &http.Server{
+		Addr:    ":" + strconv.Itoa(viper.GetInt(webhookPortKey)),
+		Handler: router,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("Webhook server forced to shutdown: %s", err)
+		}
+	}()
+
+	certDir := viper.GetString(webhookCertDirKey)
+	logger.Infow("Start to serve admission webhook", "addr", srv.Addr)
+	err := srv.ListenAndServeTLS(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err != nil && err != http.ErrServerClosed {
+		logger.Fatalf("Webhook server failed: %s", err)
+	}
+}
+
+func handleValidateKubeconfig(c *gin.Context) {
+	var review admissionv1.AdmissionReview
+	if err := c.ShouldBindJSON(&review); err != nil || review.Request == nil {
+		c.String(http.StatusBadRequest, "Invalid admission review")
+		return
+	}
+
+	response := validateKubeconfigAdmission(review.Request)
+	response.UID = review.Request.UID
+	review.Request = nil
+	review.Response = response
+
+	c.JSON(http.StatusOK, review)
+}
+
+// Validates a Kubeconfig or ClusterKubeconfig object with the same rules as the reconciler, plus
+// rules that need the raw object or the other Kubeconfigs
+func validateKubeconfigAdmission(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	clusterScoped := request.Kind.Kind == "ClusterKubeconfig"
+	if request.Operation == admissionv1.Delete || (!clusterScoped && !watchedNamespace(request.Namespace)) {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	kubeconfig, err := decodeKubeconfigObject(request.Object.Raw, clusterScoped)
+	if err != nil {
+		return deniedResponse(http.StatusBadRequest, metav1.StatusReasonBadRequest, "Cannot decode kubeconfig: "+err.Error())
+	}
+
+	// Clusters of a source are checked by the reconciler, the source may not exist yet
+	var allErrs field.ErrorList
+	if kubeconfig.Spec.KubeconfigFrom == nil {
+		allErrs = validateKubeconfigSpec(&kubeconfig.Spec)
+	} else if kubeconfig.Spec.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "name"), "display name is required"))
+	}
+	allErrs = append(allErrs, validateNoStaticCredentials(request.Object.Raw)...)
+
+	// Display names are unique across the cluster, an update keeping the name does not change
+	// that, even if older objects already share it
+	keepsDisplayName := false
+	if request.Operation == admissionv1.Update && len(request.OldObject.Raw) > 0 {
+		old, err := decodeKubeconfigObject(request.OldObject.Raw, clusterScoped)
+		if err != nil {
+			return deniedResponse(http.StatusBadRequest, metav1.StatusReasonBadRequest, "Cannot decode old kubeconfig: "+err.Error())
+		}
+		keepsDisplayName = old.Spec.Name == kubeconfig.Spec.Name
+	}
+	if !keepsDisplayName {
+		others, err := listKubeconfigObjects()
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(field.NewPath("spec", "name"), err))
+		} else {
+			allErrs = append(allErrs, validateUniqueDisplayName(kubeconfig, others)...)
+		}
+	}
+
+	if len(allErrs) > 0 {
+		logger.Infow("Kubeconfig rejected by admission webhook", "kind", request.Kind.Kind, "namespace", kubeconfig.Namespace, "name", kubeconfig.Name, "errors", allErrs.ToAggregate().Error())
+		return deniedResponse(http.StatusUnprocessableEntity, metav1.StatusReasonInvalid, allErrs.ToAggregate().Error())
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// Decodes a Kubeconfig, or a ClusterKubeconfig as a Kubeconfig without namespace
+func decodeKubeconfigObject(raw []byte, clusterScoped bool) (*v1alpha1.Kubeconfig, error) {
+	if clusterScoped {
+		var clusterKubeconfig v1alpha1.ClusterKubeconfig
+		if err := json.Unmarshal(raw, &clusterKubeconfig); err != nil {
+			return nil, err
+		}
+		return fromClusterKubeconfig(&clusterKubeconfig), nil
+	}
+	kubeconfig := &v1alpha1.Kubeconfig{}
+	if err := json.Unmarshal(raw, kubeconfig); err != nil {
+		return nil, err
+	}
+	return kubeconfig, nil
+}
+
+// Returns the Kubeconfigs of the watched namespaces and the ClusterKubeconfigs as stored in the
+// cluster, whatever the catalog provider
+func listKubeconfigObjects() ([]*v1alpha1.Kubeconfig, error) {
+	all, err := kubecfg.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	clusterKubeconfigs, err := kubecfg.clusterLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigs := make([]*v1alpha1.Kubeconfig, 0, len(all)+len(clusterKubeconfigs))
+	for _, kubeconfig := range all {
+		if watchedNamespace(kubeconfig.Namespace) {
+			kubeconfigs = append(kubeconfigs, kubeconfig)
+		}
+	}
+	for _, clusterKubeconfig := range clusterKubeconfigs {
+		kubeconfigs = append(kubeconfigs, fromClusterKubeconfig(clusterKubeconfig))
+	}
+	return kubeconfigs, nil
+}
+
+func deniedResponse(code int32, reason metav1.StatusReason, message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    code,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1alpha1 "github.com/AvistoTelecom/kubebrowser/pkg/apis/kubeconfig/v1alpha1"
+	listers "github.com/AvistoTelecom/kubebrowser/pkg/client/listers/kubeconfig/v1alpha1"
+	"github.com/spf13/viper"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Sets the listers of the cluster objects for the duration of the test
+func setTestListers(t *testing.T, kubeconfigs []*v1alpha1.Kubeconfig, clusterKubeconfigs []*v1alpha1.ClusterKubeconfig) {
+	t.Helper()
+	kubeconfigIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, kubeconfig := range kubeconfigs {
+		if err := kubeconfigIndexer.Add(kubeconfig); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clusterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, clusterKubeconfig := range clusterKubeconfigs {
+		if err := clusterIndexer.Add(clusterKubeconfig); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previousLister, previousClusterLister := kubecfg.lister, kubecfg.clusterLister
+	kubecfg.lister = listers.NewKubeconfigLister(kubeconfigIndexer)
+	kubecfg.clusterLister = listers.NewClusterKubeconfigLister(clusterIndexer)
+	t.Cleanup(func() {
+		kubecfg.lister = previousLister
+		kubecfg.clusterLister = previousClusterLister
+	})
+}
+
+func TestValidateKubeconfigAdmission(t *testing.T) {
+	viper.Set(podNamespaceKey, "kubebrowser")
+	viper.Set(watchNamespacesKey, "team-a")
+	t.Cleanup(func() {
+		viper.Set(podNamespaceKey, "")
+		viper.Set(watchNamespacesKey, "")
+	})
+	setTestListers(t,
+		[]*v1alpha1.Kubeconfig{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "staging"},
+			Spec:       testKubeconfigSpec("Staging"),
+		}},
+		[]*v1alpha1.ClusterKubeconfig{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				Spec:       testKubeconfigSpec("Shared"),
+			},
+			// Created before the webhook, with the display name of a Kubeconfig
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+				Spec:       testKubeconfigSpec("Staging"),
+			},
+		},
+	)
+
+	invalid := testKubeconfigSpec("Invalid")
+	invalid.Kubeconfig.Clusters[0].Cluster.Server = "http://invalid.example.com"
+
+	tests := []struct {
+		name      string
+		kind      string
+		namespace string
+		object    runtime.Object
+		oldObject runtime.Object
+		allowed   bool
+	}{
+		{
+			name:      "valid Kubeconfig",
+			kind:      "Kubeconfig",
+			namespace: "kubebrowser",
+			object:    &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: "prod"}, Spec: testKubeconfigSpec("Production")},
+			allowed:   true,
+		},
+		{
+			name:      "invalid Kubeconfig",
+			kind:      "Kubeconfig",
+			namespace: "team-a",
+			object:    &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "invalid"}, Spec: invalid},
+			allowed:   false,
+		},
+		{
+			name:      "Kubeconfig of an unwatched namespace",
+			kind:      "Kubeconfig",
+			namespace: "team-b",
+			object:    &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "invalid"}, Spec: invalid},
+			allowed:   true,
+		},
+		{
+			name:      "Kubeconfig named like another namespace's",
+			kind:      "Kubeconfig",
+			namespace: "kubebrowser",
+			object:    &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kubebrowser", Name: "staging"}, Spec: testKubeconfigSpec("Staging")},
+			allowed:   false,
+		},
+		{
+			name:    "valid ClusterKubeconfig",
+			kind:    "ClusterKubeconfig",
+			object:  &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "dev"}, Spec: testKubeconfigSpec("Development")},
+			allowed: true,
+		},
+		{
+			name:    "invalid ClusterKubeconfig",
+			kind:    "ClusterKubeconfig",
+			object:  &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "invalid"}, Spec: invalid},
+			allowed: false,
+		},
+		{
+			name:    "ClusterKubeconfig with a used display name",
+			kind:    "ClusterKubeconfig",
+			object:  &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "staging"}, Spec: testKubeconfigSpec("Staging")},
+			allowed: false,
+		},
+		{
+			name:    "update of a ClusterKubeconfig",
+			kind:    "ClusterKubeconfig",
+			object:  &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "shared"}, Spec: testKubeconfigSpec("Shared")},
+			allowed: true,
+		},
+		{
+			name:      "update keeping a duplicate display name",
+			kind:      "ClusterKubeconfig",
+			object:    &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}, Spec: testKubeconfigSpec("Staging")},
+			oldObject: &v1alpha1.ClusterKubeconfig{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}, Spec: testKubeconfigSpec("Staging")},
+			allowed:   true,
+		},
+		{
+			name:      "update to a used display name",
+			kind:      "Kubeconfig",
+			namespace: "team-a",
+			object:    &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "staging"}, Spec: testKubeconfigSpec("Shared")},
+			oldObject: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "staging"}, Spec: testKubeconfigSpec("Staging")},
+			allowed:   false,
+		},
+		{
+			name:      "invalid update keeping the display name",
+			kind:      "Kubeconfig",
+			namespace: "team-a",
+			object:    &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "staging"}, Spec: invalid},
+			oldObject: &v1alpha1.Kubeconfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "staging"}, Spec: invalid},
+			allowed:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := &admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: v1alpha1.SchemeGroupVersion.Group, Version: "v1alpha1", Kind: tt.kind},
+				Namespace: tt.namespace,
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}
+			if tt.oldObject != nil {
+				oldRaw, err := json.Marshal(tt.oldObject)
+				if err != nil {
+					t.Fatal(err)
+				}
+				request.Operation, request.OldObject = admissionv1.Update, runtime.RawExtension{Raw: oldRaw}
+			}
+			response := validateKubeconfigAdmission(request)
+			if response.Allowed != tt.allowed {
+				t.Errorf("Allowed = %v, want %v (%v)", response.Allowed, tt.allowed, response.Result)
+			}
+		})
+	}
+}
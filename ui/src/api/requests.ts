@@ -1,6 +1,8 @@
 import axios from 'axios'
 
+import type { Announcement } from '@/types/Announcement'
 import type { Kubeconfig } from '@/types/Kubeconfig'
+import type { Me } from '@/types/Me'
 
 export async function getMe(): Promise<string> {
   if (import.meta.env.DEV) {
@@ -10,8 +12,8 @@ export async function getMe(): Promise<string> {
   } else {
     // TODO: handle errors?
     return axios
-      .get<string>('/api/me')
-      .then((res) => res.data)
+      .get<Me>('/api/me')
+      .then((res) => res.data.name)
       .catch(() => '')
   }
 }
@@ -21,20 +23,51 @@ export async function getConfigs(): Promise<Kubeconfig[]> {
     // await new Promise((resolve) => setTimeout(resolve, 4000))
     // Mock response for development
     return [
-      { name: 'Cluster number 1', kubeconfig: { apiVersion: 'v1', kind: 'Config' } },
-      { name: 'Cluster number 2', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
-      { name: 'Cluster number 3', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
-      { name: 'Cluster number 4', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
-      { name: 'Cluster number 5', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
-      { name: 'Cluster number 6', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
-      { name: 'Cluster number 7', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
-      { name: 'Another cluster', kubeconfig: { apiVersion: 'v1', kind: 'Another' } },
+      { id: 'c1', name: 'Cluster number 1', kubeconfig: { apiVersion: 'v1', kind: 'Config' } },
+      { id: 'c2', name: 'Cluster number 2', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
+      { id: 'c3', name: 'Cluster number 3', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
+      { id: 'c4', name: 'Cluster number 4', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
+      { id: 'c5', name: 'Cluster number 5', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
+      { id: 'c6', name: 'Cluster number 6', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
+      { id: 'c7', name: 'Cluster number 7', kubeconfig: { apiVersion: 'v1', kind: 'Config2' } },
+      { id: 'c8', name: 'Another cluster', kubeconfig: { apiVersion: 'v1', kind: 'Another' } },
     ]
   } else {
     // TODO: handle errors?
     return axios
-      .get<Kubeconfig[]>('/api/kubeconfigs')
-      .then((res) => res.data.sort((a, b) => a.name.localeCompare(b.name)))
+      .get<Kubeconfig[]>('/api/kubeconfigs', { params: { sort: 'name' } })
+      .then((res) => res.data)
       .catch(() => [])
   }
 }
+
+export async function getAnnouncements(): Promise<Announcement[]> {
+  if (import.meta.env.DEV) {
+    // Mock response for development
+    return [{ name: 'a1', severity: 'info', body: 'The **prod** cluster is upgraded on Friday.' }]
+  } else {
+    return axios
+      .get<Announcement[]>('/api/announcements')
+      .then((res) => res.data)
+      .catch(() => [])
+  }
+}
+
+export interface KubeconfigEventHandlers {
+  snapshot: (kubeconfigs: Kubeconfig[]) => void
+  upsert: (kubeconfig: Kubeconfig) => void
+  remove: (id: string) => void
+}
+
+// Streams the changes of the catalog, the browser reconnects and resumes the stream by itself
+export function watchConfigs(handlers: KubeconfigEventHandlers): () => void {
+  if (import.meta.env.DEV) {
+    return () => {}
+  }
+  const source = new EventSource('/api/kubeconfigs/events')
+  source.addEventListener('snapshot', (event) => handlers.snapshot(JSON.parse(event.data)))
+  source.addEventListener('add', (event) => handlers.upsert(JSON.parse(event.data)))
+  source.addEventListener('update', (event) => handlers.upsert(JSON.parse(event.data)))
+  source.addEventListener('delete', (event) => handlers.remove(JSON.parse(event.data).id))
+  return () => source.close()
+}
@@ -0,0 +1,7 @@
+export interface Announcement {
+  name: string
+  severity: 'info' | 'warning' | 'critical'
+  body: string
+  startTime?: string
+  endTime?: string
+}
@@ -1,4 +1,53 @@
+export interface Link {
+  name: string
+  url: string
+}
+
+export interface Lifecycle {
+  state?: 'active' | 'maintenance' | 'deprecated' | 'disabled'
+  message?: string
+  sunsetDate?: string
+  replacement?: string
+}
+
+export interface ClusterProbe {
+  name: string
+  reachable: boolean
+  ready?: boolean
+  version?: string
+  certificateValid?: boolean
+  certificateAuthorityValid?: boolean
+  message?: string
+  latencyMilliseconds: number
+  probeTime: string
+}
+
+export interface CertificateAuthorityExpiry {
+  cluster: string
+  subject: string
+  notAfter: string
+  expiringSoon?: boolean
+}
+
 export interface Kubeconfig {
+  id: string
   name: string
+  description?: string
+  environment?: string
+  region?: string
+  provider?: string
+  owner?: string
+  contact?: string
+  links?: Link[]
+  tags?: string[]
+  lifecycle?: Lifecycle
   kubeconfig: object
+  namespace?: string
+  team?: string
+  source?: string
+  stale?: boolean
+  stepUpURL?: string
+  warnings?: string[]
+  probes?: ClusterProbe[]
+  certificateAuthorities?: CertificateAuthorityExpiry[]
 }
@@ -0,0 +1,10 @@
+export interface Group {
+  id: string
+  displayName?: string
+}
+
+export interface Me {
+  name: string
+  email: string
+  groups: Group[]
+}
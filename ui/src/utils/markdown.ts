@@ -0,0 +1,32 @@
+const escapes: Record<string, string> = {
+  '&': '&amp;',
+  '<': '&lt;',
+  '>': '&gt;',
+  '"': '&quot;',
+  "'": '&#39;',
+}
+
+function escapeHTML(text: string): string {
+  return text.replace(/[&<>"']/g, (char) => escapes[char])
+}
+
+// Renders the inline subset of markdown used by announcements: paragraphs, line breaks, bold,
+// italic, code and http(s) links. The text is escaped first, so no HTML gets through.
+export function renderMarkdown(markdown: string): string {
+  return markdown
+    .trim()
+    .split(/\n\s*\n/)
+    .map((paragraph) => {
+      const html = escapeHTML(paragraph)
+        .replace(/`([^`]+)`/g, '<code>$1</code>')
+        .replace(/\*\*([^*]+)\*\*/g, '<strong>$1</strong>')
+        .replace(/\*([^*]+)\*/g, '<em>$1</em>')
+        .replace(
+          /\[([^\]]+)\]\((https?:\/\/[^\s)]+)\)/g,
+          '<a class="underline" href="$2" target="_blank" rel="noopener noreferrer">$1</a>',
+        )
+        .replace(/\n/g, '<br>')
+      return `<p>${html}</p>`
+    })
+    .join('')
+}